@@ -0,0 +1,171 @@
+// Package auth authenticates the calls of the greet, calculator and blog services
+// by the bearer tokens, the JWTs issued by AuthService.Login
+// or any other tokens known to an Authenticator,
+// or by the TLS client certificates.
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Identity is the authenticated caller of a request.
+type Identity struct {
+	Subject string
+	Roles   []string
+
+	// set for a caller authenticated by its TLS client certificate
+	CertSubject string   // the distinguished name of the certificate subject
+	SANs        []string // the subject alternative names
+}
+
+// HasRole reports whether the caller has the role.
+func (id *Identity) HasRole(role string) bool {
+	for _, r := range id.Roles {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+type identityKey struct{}
+
+// NewContext returns the context of a request of the caller.
+func NewContext(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// FromContext returns the caller of the request set by the interceptors.
+func FromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(*Identity)
+
+	return id, ok
+}
+
+// Authenticator returns the caller identified by a bearer token.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*Identity, error)
+}
+
+// publicMethods are the prefixes of the methods called without a token:
+// the reflection, the health checks and the login itself.
+var publicMethods = []string{
+	"/grpc.reflection.",
+	"/grpc.health.",
+	"/auth.AuthService/Login",
+}
+
+// isPublic reports whether the method is called without a token.
+func isPublic(fullMethod string) bool {
+	for _, prefix := range publicMethods {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// identifyFunc returns the context of the request with its authenticated caller.
+type identifyFunc func(ctx context.Context) (context.Context, error)
+
+// tokenIdentity authenticates the bearer token of the authorization metadata of the request.
+func tokenIdentity(a Authenticator) identifyFunc {
+	return func(ctx context.Context) (context.Context, error) {
+		return authenticate(ctx, a)
+	}
+}
+
+// authenticate authenticates the bearer token
+// of the authorization metadata of the request.
+func authenticate(ctx context.Context, a Authenticator) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "The request has no authorization token")
+	}
+
+	const prefix = "bearer "
+
+	token := values[0]
+	if len(token) <= len(prefix) || !strings.EqualFold(token[:len(prefix)], prefix) {
+		return nil, status.Error(codes.Unauthenticated, "The authorization is not a bearer token")
+	}
+
+	id, err := a.Authenticate(ctx, token[len(prefix):])
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "Invalid authorization token: %v", err)
+	}
+
+	return NewContext(ctx, id), nil
+}
+
+// UnaryServerInterceptor rejects the unary requests without a valid bearer token
+// with Unauthenticated. The caller is set to the context of the handler.
+func UnaryServerInterceptor(a Authenticator) grpc.UnaryServerInterceptor {
+	return unaryInterceptor(tokenIdentity(a))
+}
+
+// StreamServerInterceptor rejects the streams without a valid bearer token
+// with Unauthenticated. The caller is set to the context of the stream.
+func StreamServerInterceptor(a Authenticator) grpc.StreamServerInterceptor {
+	return streamInterceptor(tokenIdentity(a))
+}
+
+func unaryInterceptor(identify identifyFunc) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if isPublic(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		ctx, err := identify(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func streamInterceptor(identify identifyFunc) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if isPublic(info.FullMethod) {
+			return handler(srv, stream)
+		}
+
+		ctx, err := identify(stream.Context())
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{stream, ctx})
+	}
+}
+
+// serverStream is a server stream with the context of the authenticated caller.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
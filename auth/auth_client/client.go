@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/sergeyzalunin/grpc-go-course/auth/authpb"
+	"github.com/sergeyzalunin/grpc-go-course/clientkit"
+	"github.com/sergeyzalunin/grpc-go-course/config"
+	"google.golang.org/grpc"
+)
+
+// envPrefix is the prefix of the environment variables of the client config.
+const envPrefix = "AUTH_"
+
+// clientConfig is the config of the client loaded by the config package.
+type clientConfig struct {
+	Addr string        `yaml:"addr" help:"address of the server"`
+	TLS  clientkit.TLS `yaml:"tls"`
+}
+
+func (c *clientConfig) Validate(check *config.Check) {
+	check.Addr("addr", c.Addr)
+	c.TLS.Validate(check)
+}
+
+// logs in and prints the token, the password is read from stdin:
+//
+//	export BLOG_TOKEN=$(echo "$PASSWORD" | go run . -user ninja -audience blog)
+func main() {
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	username := flag.String("user", "", "username of the users file of the server")
+	audience := flag.String("audience", "blog", "service of the token: greet, calculator or blog")
+
+	cfg := &clientConfig{
+		Addr: "localhost:50053",
+		TLS:  clientkit.TLS{Enabled: true, CAFile: clientkit.DefaultCAFile},
+	}
+
+	if err := config.Load(cfg, envPrefix); err != nil {
+		log.Fatal(err)
+	}
+
+	password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && password == "" {
+		log.Fatalf("Cannot read the password: %v", err)
+	}
+
+	creds, err := cfg.TLS.DialOption()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cc, err := grpc.Dial(cfg.Addr, creds)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer cc.Close()
+
+	c := authpb.NewAuthServiceClient(cc)
+
+	res, err := c.Login(context.Background(), &authpb.LoginRequest{
+		Username: *username,
+		Password: strings.TrimRight(password, "\r\n"),
+		Audience: *audience,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(res.GetToken())
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sergeyzalunin/grpc-go-course/auth"
+	"github.com/sergeyzalunin/grpc-go-course/auth/authpb"
+	"github.com/sergeyzalunin/grpc-go-course/config"
+	"github.com/sergeyzalunin/grpc-go-course/serverkit"
+)
+
+// envPrefix is the prefix of the environment variables of the server config.
+const envPrefix = "AUTH_SERVER_"
+
+// serverConfig is the config of the server loaded by the config package.
+type serverConfig struct {
+	Server    serverkit.Config `yaml:",inline"`
+	Users     string           `yaml:"users" help:"file of the users: a username, a password hash and roles per line"`
+	Keys      string           `yaml:"keys" help:"directory of the token signing keys"`
+	TokenTTL  time.Duration    `yaml:"token_ttl" help:"how long an issued token is valid"`
+	Audiences []string         `yaml:"audiences" help:"comma separated services the tokens are issued for"`
+
+	// the commands run instead of the server
+	newKey       *string
+	hashPassword *bool
+}
+
+func (c *serverConfig) Validate(check *config.Check) {
+	if *c.newKey != "" || *c.hashPassword {
+		return
+	}
+
+	c.Server.Validate(check)
+	check.File("users", c.Users)
+	check.File("keys", c.Keys)
+
+	if c.TokenTTL <= 0 {
+		check.Errorf("token_ttl", "must be positive")
+	}
+
+	if len(c.Audiences) == 0 {
+		check.Errorf("audiences", "is required")
+	}
+}
+
+func main() {
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	cfg := &serverConfig{
+		Server:    serverkit.DefaultConfig("auth", "localhost:50053"),
+		Users:     "users.txt",
+		Keys:      "keys",
+		TokenTTL:  time.Hour,
+		Audiences: []string{"greet", "calculator", "blog"},
+		newKey:    flag.String("new-key", "", "generate a signing key with the key ID in the keys directory and exit"),
+		hashPassword: flag.Bool("hash-password", false,
+			"read a password from stdin, print its hash for the users file and exit"),
+	}
+	cfg.Server.TLS.Enabled = true
+
+	if err := config.Load(cfg, envPrefix); err != nil {
+		log.Fatal(err)
+	}
+
+	switch {
+	case *cfg.newKey != "":
+		if err := auth.GenerateKey(cfg.Keys, *cfg.newKey); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Generated key %s, the new tokens are signed by it when it is the last key ID\n", *cfg.newKey)
+
+		return
+	case *cfg.hashPassword:
+		password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && password == "" {
+			log.Fatal(err)
+		}
+
+		hash, err := auth.HashPassword(strings.TrimRight(password, "\r\n"))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Println(hash)
+
+		return
+	}
+
+	fmt.Println("Auth server started...")
+
+	users, err := auth.LoadUsers(cfg.Users)
+	if err != nil {
+		log.Fatalf("Cannot load the users: %v", err)
+	}
+
+	keys, err := auth.LoadKeys(cfg.Keys)
+	if err != nil {
+		log.Fatalf("Cannot load the keys: %v", err)
+	}
+
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+
+	// the rotated keys are picked up without a restart
+	go keys.Watch(watchCtx, time.Minute)
+
+	s, err := serverkit.New(cfg.Server)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	authpb.RegisterAuthServiceServer(s.GRPC, &auth.Service{
+		Users:     users,
+		Issuer:    &auth.TokenIssuer{Keys: keys, TTL: cfg.TokenTTL},
+		Audiences: cfg.Audiences,
+	})
+
+	if err = s.Run(context.Background()); err != nil {
+		log.Print(err)
+	}
+
+	fmt.Println("End of Program")
+}
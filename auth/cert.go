@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// CertUnaryServerInterceptor sets the caller identified by the TLS client certificate
+// to the context of the handler, see CertIdentity.
+// The server must require and verify the client certificates.
+func CertUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return unaryInterceptor(certIdentity)
+}
+
+// CertStreamServerInterceptor sets the caller identified by the TLS client certificate
+// to the context of the stream, see CertIdentity.
+// The server must require and verify the client certificates.
+func CertStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return streamInterceptor(certIdentity)
+}
+
+func certIdentity(ctx context.Context) (context.Context, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "The request has no peer")
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "The request has no verified client certificate")
+	}
+
+	id, err := CertIdentity(info.State.VerifiedChains[0][0])
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "Invalid client certificate: %v", err)
+	}
+
+	return NewContext(ctx, id), nil
+}
+
+// CertIdentity returns the caller of a client certificate:
+// the subject is the common name, or the first SAN without it,
+// and the roles are the organizational units.
+// The subject must be a valid username, as it becomes the author ID of the blogs.
+func CertIdentity(cert *x509.Certificate) (*Identity, error) {
+	id := &Identity{
+		Subject:     cert.Subject.CommonName,
+		Roles:       cert.Subject.OrganizationalUnit,
+		CertSubject: cert.Subject.String(),
+	}
+
+	id.SANs = append(id.SANs, cert.DNSNames...)
+	id.SANs = append(id.SANs, cert.EmailAddresses...)
+
+	for _, ip := range cert.IPAddresses {
+		id.SANs = append(id.SANs, ip.String())
+	}
+
+	for _, uri := range cert.URIs {
+		id.SANs = append(id.SANs, uri.String())
+	}
+
+	if id.Subject == "" && len(id.SANs) > 0 {
+		id.Subject = id.SANs[0]
+	}
+
+	switch {
+	case id.Subject == "":
+		return nil, errors.New("no subject")
+	case !usernamePattern.MatchString(id.Subject):
+		return nil, fmt.Errorf("subject %q may contain only up to 64 letters, digits, '_', '.' and '-'", id.Subject)
+	}
+
+	return id, nil
+}
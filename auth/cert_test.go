@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCertIdentity(t *testing.T) {
+	tests := []struct {
+		name    string
+		cert    *x509.Certificate
+		subject string
+		wantErr bool
+	}{
+		{
+			"common name",
+			&x509.Certificate{
+				Subject:  pkix.Name{CommonName: "ninja", OrganizationalUnit: []string{"admin"}},
+				DNSNames: []string{"client.example.com"},
+			},
+			"ninja",
+			false,
+		},
+		{
+			"first SAN",
+			&x509.Certificate{DNSNames: []string{"client.example.com"}, IPAddresses: []net.IP{net.IPv4(127, 0, 0, 1)}},
+			"client.example.com",
+			false,
+		},
+		{"no subject", &x509.Certificate{}, "", true},
+		{"common name with spaces", &x509.Certificate{Subject: pkix.Name{CommonName: "Ninja Turtle"}}, "", true},
+		{"common name too long", &x509.Certificate{Subject: pkix.Name{CommonName: strings.Repeat("n", 65)}}, "", true},
+		{"email SAN", &x509.Certificate{EmailAddresses: []string{"ninja@example.com"}}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := CertIdentity(tt.cert)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CertIdentity() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if id.Subject != tt.subject {
+				t.Errorf("Subject = %q, want %q", id.Subject, tt.subject)
+			}
+
+			if !reflect.DeepEqual(id.Roles, tt.cert.Subject.OrganizationalUnit) {
+				t.Errorf("Roles = %v, want %v", id.Roles, tt.cert.Subject.OrganizationalUnit)
+			}
+		})
+	}
+}
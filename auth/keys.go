@@ -0,0 +1,236 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// errUnknownKey is returned for a token signed by a key which is not in the key set.
+var errUnknownKey = errors.New("unknown signing key")
+
+// KeySet is the Ed25519 keys of the tokens kept in a directory,
+// PEM files named after the key IDs: <kid>.pem holds a PKCS #8 private key,
+// to sign and verify the tokens, and <kid>.pub a PKIX public key,
+// to verify them only.
+//
+// The keys are rotated by adding a key with a later ID:
+// the tokens are signed by the private key with the last ID in the sort order,
+// so the IDs are better named by date, and the tokens of the older keys
+// are verified until their files are removed.
+type KeySet struct {
+	dir string
+
+	mu         sync.RWMutex
+	public     map[string]ed25519.PublicKey
+	signingKID string
+	signingKey ed25519.PrivateKey
+}
+
+// LoadKeys reads the keys of the directory.
+func LoadKeys(dir string) (*KeySet, error) {
+	ks := &KeySet{dir: dir}
+	if err := ks.Reload(); err != nil {
+		return nil, err
+	}
+
+	return ks, nil
+}
+
+// Reload reads the keys of the directory again,
+// the current keys are kept when they can't be read.
+func (ks *KeySet) Reload() error {
+	var files []string
+
+	for _, pattern := range []string{"*.pem", "*.pub"} {
+		matches, err := filepath.Glob(filepath.Join(ks.dir, pattern))
+		if err != nil {
+			return err
+		}
+
+		files = append(files, matches...)
+	}
+
+	sort.Strings(files)
+
+	public := make(map[string]ed25519.PublicKey, len(files))
+
+	var (
+		signingKID string
+		signingKey ed25519.PrivateKey
+	)
+
+	for _, file := range files {
+		kid := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+
+		pub, priv, err := readKey(file)
+		if err != nil {
+			return fmt.Errorf("key %s: %w", kid, err)
+		}
+
+		public[kid] = pub
+
+		if priv != nil {
+			signingKID, signingKey = kid, priv
+		}
+	}
+
+	if len(public) == 0 {
+		return fmt.Errorf("no keys in %s", ks.dir)
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	ks.public = public
+	ks.signingKID, ks.signingKey = signingKID, signingKey
+
+	return nil
+}
+
+// Watch reloads the keys every interval until the context is done,
+// so the added and the removed keys are picked up without a restart.
+func (ks *KeySet) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := ks.Reload(); err != nil {
+			log.Printf("Cannot reload the keys: %v", err)
+		}
+	}
+}
+
+// publicKey returns the key verifying the tokens of the key ID.
+func (ks *KeySet) publicKey(kid string) (ed25519.PublicKey, error) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	key, ok := ks.public[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errUnknownKey, kid)
+	}
+
+	return key, nil
+}
+
+// privateKey returns the key signing the new tokens and its ID.
+func (ks *KeySet) privateKey() (string, ed25519.PrivateKey, error) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	if ks.signingKey == nil {
+		return "", nil, fmt.Errorf("no private keys in %s", ks.dir)
+	}
+
+	return ks.signingKID, ks.signingKey, nil
+}
+
+// readKey reads a PEM file of a private or a public key,
+// the private key is nil for a public key.
+func readKey(file string) (ed25519.PublicKey, ed25519.PrivateKey, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, nil, errors.New("not a PEM file")
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		priv, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return nil, nil, errors.New("not an Ed25519 key")
+		}
+
+		return priv.Public().(ed25519.PublicKey), priv, nil
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return nil, nil, errors.New("not an Ed25519 key")
+		}
+
+		return pub, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unexpected PEM block %q", block.Type)
+	}
+}
+
+// GenerateKey writes a new private key with the key ID to the directory
+// and its public key to <kid>.pub for the services which only verify the tokens.
+func GenerateKey(dir, kid string) error {
+	if kid == "" || strings.ContainsAny(kid, `/\.`) {
+		return fmt.Errorf("invalid key ID %q", kid)
+	}
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return err
+	}
+
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	// don't overwrite the key of the issued tokens
+	f, err := os.OpenFile(filepath.Join(dir, kid+".pem"), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if err = pem.Encode(f, &pem.Block{Type: "PRIVATE KEY", Bytes: privDER}); err != nil {
+		f.Close()
+
+		return err
+	}
+
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	return os.WriteFile(
+		filepath.Join(dir, kid+".pub"),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}),
+		0o644, //nolint
+	)
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sergeyzalunin/grpc-go-course/auth/authpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Service is the AuthService issuing the tokens of the users of the user file.
+type Service struct {
+	Users     Users
+	Issuer    *TokenIssuer
+	Audiences []string // the services the tokens are issued for
+}
+
+func (s *Service) Login(_ context.Context, req *authpb.LoginRequest) (*authpb.LoginResponse, error) {
+	fmt.Println("Login")
+
+	if !s.knownAudience(req.GetAudience()) {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Unknown audience %q, want one of: %s",
+			req.GetAudience(),
+			strings.Join(s.Audiences, ", "),
+		)
+	}
+
+	id, err := s.Users.Check(req.GetUsername(), req.GetPassword())
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "Wrong username or password")
+	}
+
+	token, expire, err := s.Issuer.Issue(id, req.GetAudience())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"Cannot issue the token: %v",
+			err,
+		)
+	}
+
+	return &authpb.LoginResponse{
+		Token:      token,
+		ExpireTime: timestamppb.New(expire),
+	}, nil
+}
+
+func (s *Service) knownAudience(audience string) bool {
+	for _, a := range s.Audiences {
+		if a == audience {
+			return true
+		}
+	}
+
+	return false
+}
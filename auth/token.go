@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Issuer is the issuer of the tokens, checked by the verifiers.
+const Issuer = "grpc-go-course"
+
+// clockSkew is the leeway of the expiry of a token
+// for the clocks of the issuer and the service not quite in sync.
+const clockSkew = 30 * time.Second
+
+// claims are the claims of a token.
+type claims struct {
+	jwt.RegisteredClaims
+	Roles []string `json:"roles,omitempty"`
+}
+
+// TokenIssuer signs the tokens of the callers by the latest private key of the key set.
+type TokenIssuer struct {
+	Keys *KeySet
+	TTL  time.Duration // how long a token is valid
+}
+
+// Issue returns a token of the caller for the service of the audience
+// and the time it expires.
+func (i *TokenIssuer) Issue(id *Identity, audience string) (string, time.Time, error) {
+	kid, key, err := i.Keys.privateKey()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now()
+	expire := now.Add(i.TTL).Truncate(time.Second)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    Issuer,
+			Subject:   id.Subject,
+			Audience:  jwt.ClaimStrings{audience},
+			ExpiresAt: jwt.NewNumericDate(expire),
+			NotBefore: jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+		Roles: id.Roles,
+	})
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return signed, expire, nil
+}
+
+// TokenVerifier authenticates the tokens of a service:
+// the signature by a key of the key set, the expiry, the issuer and the audience.
+type TokenVerifier struct {
+	Keys     *KeySet
+	Audience string // the name of the service
+}
+
+func (v *TokenVerifier) Authenticate(_ context.Context, token string) (*Identity, error) {
+	var c claims
+
+	_, err := jwt.ParseWithClaims(token, &c, v.key,
+		jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithIssuer(Issuer),
+		jwt.WithAudience(v.Audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(clockSkew),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Subject == "" {
+		return nil, errors.New("token has no subject")
+	}
+
+	return &Identity{
+		Subject: c.Subject,
+		Roles:   c.Roles,
+	}, nil
+}
+
+// key returns the public key of the key ID of the token.
+func (v *TokenVerifier) key(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	return v.Keys.publicKey(kid)
+}
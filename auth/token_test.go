@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// tokenKID returns the key ID in the header of the token.
+func tokenKID(t *testing.T, token string) string {
+	t.Helper()
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &claims{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kid, _ := parsed.Header["kid"].(string)
+
+	return kid
+}
+
+func TestKeyRotation(t *testing.T) {
+	dir := t.TempDir()
+	if err := GenerateKey(dir, "2024-01"); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := LoadKeys(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuer := &TokenIssuer{Keys: keys, TTL: time.Hour}
+	verifier := &TokenVerifier{Keys: keys, Audience: "blog"}
+	id := &Identity{Subject: "ninja", Roles: []string{"admin"}}
+
+	old, _, err := issuer.Issue(id, "blog")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if kid := tokenKID(t, old); kid != "2024-01" {
+		t.Errorf("kid = %q, want 2024-01", kid)
+	}
+
+	// a later key signs the new tokens, the old tokens are still verified
+	if err = GenerateKey(dir, "2024-02"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = keys.Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	current, _, err := issuer.Issue(id, "blog")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if kid := tokenKID(t, current); kid != "2024-02" {
+		t.Errorf("kid after rotation = %q, want 2024-02", kid)
+	}
+
+	for _, token := range []string{old, current} {
+		got, err := verifier.Authenticate(context.Background(), token)
+		if err != nil {
+			t.Fatalf("Authenticate() error = %v", err)
+		}
+
+		if got.Subject != "ninja" || !got.HasRole("admin") {
+			t.Errorf("Authenticate() = %+v, want the issued identity", got)
+		}
+	}
+
+	// the tokens of a removed key are not verified any more
+	for _, ext := range []string{".pem", ".pub"} {
+		if err = os.Remove(filepath.Join(dir, "2024-01"+ext)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = keys.Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = verifier.Authenticate(context.Background(), old); !errors.Is(err, errUnknownKey) {
+		t.Errorf("Authenticate() of a removed key error = %v, want errUnknownKey", err)
+	}
+
+	if _, err = verifier.Authenticate(context.Background(), current); err != nil {
+		t.Errorf("Authenticate() of the current key error = %v", err)
+	}
+}
+
+func TestTokenVerifierRejects(t *testing.T) {
+	dir := t.TempDir()
+	if err := GenerateKey(dir, "k1"); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := LoadKeys(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := &Identity{Subject: "ninja"}
+
+	tests := []struct {
+		name     string
+		ttl      time.Duration
+		audience string
+	}{
+		{"other audience", time.Hour, "greet"},
+		{"expired", -time.Hour, "blog"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, _, err := (&TokenIssuer{Keys: keys, TTL: tt.ttl}).Issue(id, tt.audience)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			verifier := &TokenVerifier{Keys: keys, Audience: "blog"}
+			if _, err = verifier.Authenticate(context.Background(), token); err == nil {
+				t.Error("Authenticate() returned no error")
+			}
+		})
+	}
+}
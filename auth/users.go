@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// errBadCredentials is returned for an unknown user or a wrong password.
+var errBadCredentials = errors.New("wrong username or password")
+
+// usernamePattern is the pattern of the usernames,
+// so a username fits the author ID of a blog.
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,64}$`)
+
+// dummyHash is compared with the password of an unknown user,
+// so the unknown users take as long to check as the known ones.
+var dummyHash = []byte("$2a$10$iAekjXAnTWcsWeCYqbVWiubs6vUNY8hIC/tISuIHnNE5jmnb263KK")
+
+type user struct {
+	hash  []byte
+	roles []string
+}
+
+// Users are the users of the local user file.
+type Users map[string]*user
+
+// LoadUsers reads the user file. Every line is a username, the bcrypt hash
+// of the password and optionally the comma separated roles, separated by spaces:
+//
+//	ninja $2a$10$... admin
+//
+// The empty lines and the lines starting with '#' are skipped.
+// HashPassword makes the hash.
+func LoadUsers(path string) (Users, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	users := Users{}
+	scanner := bufio.NewScanner(f)
+
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		if len(fields) < 2 || len(fields) > 3 {
+			return nil, fmt.Errorf("%s:%d: want a username, a password hash and optional roles", path, line)
+		}
+
+		if !usernamePattern.MatchString(fields[0]) {
+			return nil, fmt.Errorf("%s:%d: username may contain only up to 64 letters, digits, '_', '.' and '-'",
+				path, line)
+		}
+
+		if _, err = bcrypt.Cost([]byte(fields[1])); err != nil {
+			return nil, fmt.Errorf("%s:%d: password hash: %w", path, line, err)
+		}
+
+		u := &user{hash: []byte(fields[1])}
+		if len(fields) == 3 {
+			u.roles = strings.Split(fields[2], ",")
+		}
+
+		users[fields[0]] = u
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// Check returns the identity of the user when the password is right.
+func (u Users) Check(username, password string) (*Identity, error) {
+	usr, ok := u[username]
+	if !ok {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+
+		return nil, errBadCredentials
+	}
+
+	if err := bcrypt.CompareHashAndPassword(usr.hash, []byte(password)); err != nil {
+		return nil, errBadCredentials
+	}
+
+	return &Identity{
+		Subject: username,
+		Roles:   usr.roles,
+	}, nil
+}
+
+// HashPassword returns the hash of the password for the user file.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestDummyHash(t *testing.T) {
+	cost, err := bcrypt.Cost(dummyHash)
+	if err != nil {
+		t.Fatalf("dummyHash is not a bcrypt hash: %v", err)
+	}
+
+	// the unknown users take as long as the passwords hashed by HashPassword
+	hash, err := HashPassword("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cost != want {
+		t.Errorf("dummyHash cost = %d, want %d", cost, want)
+	}
+
+	if err = bcrypt.CompareHashAndPassword(dummyHash, []byte("secret")); !errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		t.Errorf("CompareHashAndPassword(dummyHash) error = %v, want a mismatch", err)
+	}
+}
+
+func TestLoadUsers(t *testing.T) {
+	hash, err := HashPassword("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"users", "# users\n\nninja " + hash + " admin,editor\nbob " + hash + "\n", false},
+		{"no hash", "ninja\n", true},
+		{"bad hash", "ninja secret\n", true},
+		{"bad username", "nin/ja " + hash + "\n", true},
+		{"too many fields", "ninja " + hash + " admin extra\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "users")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			users, err := LoadUsers(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadUsers() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			id, err := users.Check("ninja", "secret")
+			if err != nil || id.Subject != "ninja" || !id.HasRole("editor") {
+				t.Errorf("Check(ninja) = %+v, %v", id, err)
+			}
+
+			for _, c := range []struct{ username, password string }{
+				{"ninja", "wrong"},
+				{"nobody", "secret"},
+			} {
+				if _, err = users.Check(c.username, c.password); !errors.Is(err, errBadCredentials) {
+					t.Errorf("Check(%s, %s) error = %v, want errBadCredentials", c.username, c.password, err)
+				}
+			}
+		})
+	}
+}
@@ -3,22 +3,31 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"log"
+	"time"
 
 	"github.com/sergeyzalunin/grpc-go-course/blog/blogpb"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
+	"github.com/sergeyzalunin/grpc-go-course/clientkit"
+	"github.com/sergeyzalunin/grpc-go-course/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 func main() {
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
-	fmt.Println("Blog client")
 
-	opts := getTLSClientOptions(true)
+	cfg := defaultClientConfig()
+	if err := config.Load(cfg, envPrefix); err != nil {
+		log.Fatal(err)
+	}
 
-	cc, err := grpc.Dial("localhost:50052", opts)
+	fmt.Println("Blog client")
+
+	cc, err := clientkit.Dial(cfg.Client)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -27,40 +36,35 @@ func main() {
 
 	c := blogpb.NewBlogServiceClient(cc)
 
+	if flag.NArg() > 0 {
+		if err = runCommand(c, flag.Args()); err != nil {
+			log.Fatal(err)
+		}
+
+		return
+	}
+
 	blog := &blogpb.Blog{
 		Id:       "",
-		AuthorId: "Ninja",
 		Title:    "My first blog",
 		Content:  "Content of the first blog",
+		Tags:     []string{"go", "grpc"},
+		Category: "tutorials",
 	}
 
+	// go watchBlogs(c, "")
 	createNewBlog(c, blog)
 	readBlog(c, blog.Id)
 	updateBlog(c, blog.Id)
 	getListBlog(c)
+	getBlogsByPages(c, blog.AuthorId)
+	searchBlogs(c, "changed content")
+	listTags(c, "")
+	batchGetBlogs(c, []string{blog.Id, "000000000000000000000000"})
+	commentBlog(blogpb.NewCommentServiceClient(cc), blog.Id)
 	deleteBlog(c, blog)
-}
-
-// getTLSClientOptions returns already setup TLS dial option
-// when tls parameter is true
-// to use it, start gen_cert.sh manualy to generate certificates
-func getTLSClientOptions(tls bool) grpc.DialOption {
-	var opts grpc.DialOption
-
-	if tls {
-		certFile := "../../ssl/minica.pem" // Certificate Authority Trust certificate
-
-		creds, sslErr := credentials.NewClientTLSFromFile(certFile, "")
-		if sslErr == nil {
-			opts = grpc.WithTransportCredentials(creds)
-		} else {
-			log.Fatalf("Filed loading certificates: %v", sslErr)
-		}
-	} else {
-		opts = grpc.WithInsecure()
-	}
-
-	return opts
+	undeleteBlog(c, blog.Id)
+	purgeBlog(c, blog.Id)
 }
 
 // create a new blog.
@@ -71,6 +75,7 @@ func createNewBlog(c blogpb.BlogServiceClient, blog *blogpb.Blog) {
 	if err == nil {
 		fmt.Printf("%+v\n\n", blogRes.GetBlog())
 		blog.Id = blogRes.Blog.Id
+		blog.AuthorId = blogRes.Blog.AuthorId
 	} else {
 		fmt.Println(err)
 	}
@@ -91,14 +96,15 @@ func readBlog(c blogpb.BlogServiceClient, blogID string) {
 // updating a blog by its ID.
 func updateBlog(c blogpb.BlogServiceClient, blogID string) {
 	updatedBlog := &blogpb.Blog{
-		Id:       blogID,
-		AuthorId: "TESTER",
-		Title:    "Changed Name",
-		Content:  "Changed content",
+		Id:      blogID,
+		Title:   "Changed Name",
+		Content: "Changed content",
 	}
 
+	// the author is kept, only an admin can change it
 	updBlog, err := c.UpdateBlog(context.Background(), &blogpb.UpdateBlogRequest{
-		Blog: updatedBlog,
+		Blog:       updatedBlog,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title", "content"}},
 	})
 	if err == nil {
 		fmt.Printf("%+v\n\n", updBlog.GetBlog())
@@ -133,10 +139,90 @@ func getListBlog(c blogpb.BlogServiceClient) {
 	}
 }
 
+// reading blogs of the author page by page.
+func getBlogsByPages(c blogpb.BlogServiceClient, authorID string) {
+	fmt.Println("Requesting blogs by pages....")
+
+	req := &blogpb.ListBlogRequest{
+		PageSize: 10, //nolint
+		AuthorId: authorID,
+		OrderBy:  blogpb.ListBlogRequest_TITLE,
+	}
+
+	for {
+		res, err := c.ListBlogsPage(context.Background(), req)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, blog := range res.GetBlogs() {
+			fmt.Printf("%+v\n\n", blog)
+		}
+
+		if res.GetNextPageToken() == "" {
+			// it was the last page
+			break
+		}
+
+		req.PageToken = res.GetNextPageToken()
+	}
+}
+
+// searching blogs by words of their title and content.
+func searchBlogs(c blogpb.BlogServiceClient, query string) {
+	res, err := c.SearchBlogs(context.Background(), &blogpb.SearchBlogsRequest{
+		Query: query,
+	})
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	for _, result := range res.GetResults() {
+		fmt.Printf("%.2f %s\n%s\n\n", result.GetScore(), result.GetTitleSnippet(), result.GetContentSnippet())
+	}
+}
+
+// watching the changes of the blogs,
+// it reconnects with the last resume token when the stream breaks.
+func watchBlogs(c blogpb.BlogServiceClient, authorID string) {
+	resumeToken := ""
+
+	for {
+		stream, err := c.WatchBlogs(context.Background(), &blogpb.WatchBlogsRequest{
+			AuthorId:    authorID,
+			ResumeToken: resumeToken,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for {
+			ev, err := stream.Recv()
+			if err != nil {
+				fmt.Printf("Watch is broken, reconnecting: %v\n", err)
+
+				if status.Code(err) == codes.OutOfRange {
+					// the missed events are lost, start with the new ones
+					resumeToken = ""
+				}
+
+				break
+			}
+
+			fmt.Printf("%v %+v\n\n", ev.GetType(), ev.GetBlog())
+			resumeToken = ev.GetResumeToken()
+		}
+
+		time.Sleep(1 * time.Second)
+	}
+}
+
 // deleting blog.
 func deleteBlog(c blogpb.BlogServiceClient, blog *blogpb.Blog) {
 	delBlog, err := c.DeleteBlog(context.Background(), &blogpb.DeleteBlogRequest{
-		Blog: blog,
+		BlogId: blog.GetId(),
 	})
 	if err != nil {
 		fmt.Println(err)
@@ -144,3 +230,67 @@ func deleteBlog(c blogpb.BlogServiceClient, blog *blogpb.Blog) {
 		fmt.Printf("%v\n\n", delBlog.GetBlogId())
 	}
 }
+
+// restoring deleted blog from the trash.
+func undeleteBlog(c blogpb.BlogServiceClient, blogID string) {
+	res, err := c.UndeleteBlog(context.Background(), &blogpb.UndeleteBlogRequest{
+		BlogId: blogID,
+	})
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Printf("%v\n\n", res.GetBlog())
+	}
+}
+
+// deleting blog permanently.
+func purgeBlog(c blogpb.BlogServiceClient, blogID string) {
+	res, err := c.PurgeBlog(context.Background(), &blogpb.PurgeBlogRequest{
+		BlogId: blogID,
+	})
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Printf("%v\n\n", res.GetBlogId())
+	}
+}
+
+// reading several blogs at once, every blog has its own status.
+func batchGetBlogs(c blogpb.BlogServiceClient, blogIDs []string) {
+	res, err := c.BatchGetBlogs(context.Background(), &blogpb.BatchGetBlogsRequest{
+		BlogIds: blogIDs,
+	})
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	for _, result := range res.GetResults() {
+		if codes.Code(result.GetCode()) == codes.OK {
+			fmt.Printf("%v\n", result.GetBlog())
+		} else {
+			fmt.Printf("%s: %s\n", result.GetBlogId(), result.GetMessage())
+		}
+	}
+
+	fmt.Println()
+}
+
+// counting the tags of the blogs of the category, all of them when it is empty.
+func listTags(c blogpb.BlogServiceClient, category string) {
+	res, err := c.ListTags(context.Background(), &blogpb.ListTagsRequest{
+		Category: category,
+	})
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	for _, tag := range res.GetTags() {
+		fmt.Printf("%s: %d\n", tag.GetTag(), tag.GetCount())
+	}
+
+	fmt.Println()
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/sergeyzalunin/grpc-go-course/blog/blogpb"
+)
+
+// commenting the blog, replying to the comment and approving both of them.
+func commentBlog(c blogpb.CommentServiceClient, blogID string) {
+	comment, err := createComment(c, &blogpb.Comment{
+		BlogId:  blogID,
+		Content: "Nice blog",
+	})
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	reply, err := createComment(c, &blogpb.Comment{
+		BlogId:   blogID,
+		ParentId: comment.GetId(),
+		Content:  "Thank you",
+	})
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	moderateComment(c, comment.GetId(), blogpb.Comment_APPROVED)
+	moderateComment(c, reply.GetId(), blogpb.Comment_APPROVED)
+	listComments(c, blogID)
+}
+
+func createComment(c blogpb.CommentServiceClient, comment *blogpb.Comment) (*blogpb.Comment, error) {
+	res, err := c.CreateComment(context.Background(), &blogpb.CreateCommentRequest{
+		Comment: comment,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("%v\n\n", res.GetComment())
+
+	return res.GetComment(), nil
+}
+
+func moderateComment(c blogpb.CommentServiceClient, commentID string, state blogpb.Comment_State) {
+	res, err := c.ModerateComment(context.Background(), &blogpb.ModerateCommentRequest{
+		CommentId: commentID,
+		State:     state,
+	})
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Printf("%v\n\n", res.GetComment())
+	}
+}
+
+// streaming the approved comments of the blog.
+func listComments(c blogpb.CommentServiceClient, blogID string) {
+	stream, err := c.ListComments(context.Background(), &blogpb.ListCommentsRequest{
+		BlogId: blogID,
+		States: []blogpb.Comment_State{blogpb.Comment_APPROVED},
+	})
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	for {
+		res, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			fmt.Println(err)
+
+			return
+		}
+
+		fmt.Printf("%v\n", res.GetComment())
+	}
+
+	fmt.Println()
+}
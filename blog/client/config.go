@@ -0,0 +1,33 @@
+package main
+
+import (
+	"github.com/sergeyzalunin/grpc-go-course/clientkit"
+	"github.com/sergeyzalunin/grpc-go-course/config"
+)
+
+// envPrefix is the prefix of the environment variables of the client config.
+const envPrefix = "BLOG_"
+
+// clientConfig is the config of the client loaded by the config package.
+type clientConfig struct {
+	Client clientkit.Config `yaml:",inline"`
+}
+
+func defaultClientConfig() *clientConfig {
+	cfg := &clientConfig{
+		Client: clientkit.DefaultConfig("localhost:50052"),
+	}
+	cfg.Client.TLS.Enabled = true
+
+	return cfg
+}
+
+func (c *clientConfig) Validate(check *config.Check) {
+	c.Client.Validate(check)
+
+	// the server authenticates every call by the token or by the client certificate
+	if c.Client.Token.Value() == "" && c.Client.TLS.CertFile == "" {
+		check.Errorf("token", "is required without a client certificate, set %sTOKEN or %sTOKEN_FILE",
+			envPrefix, envPrefix)
+	}
+}
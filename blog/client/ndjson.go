@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/sergeyzalunin/grpc-go-course/blog/blogpb"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// maxLineSize limits a line of the NDJSON file, so a blog with a big content fits.
+const maxLineSize = 16 << 20
+
+const usage = `usage:
+  client                        runs the demo
+  client import FILE            imports the blogs from the NDJSON file
+  client export FILE [AUTHOR]   exports the blogs to the NDJSON file
+FILE "-" means stdin or stdout`
+
+// runCommand runs the import or export subcommand.
+func runCommand(c blogpb.BlogServiceClient, args []string) error {
+	switch {
+	case len(args) == 2 && args[0] == "import":
+		return importBlogs(c, args[1])
+	case len(args) >= 2 && len(args) <= 3 && args[0] == "export":
+		authorID := ""
+		if len(args) == 3 {
+			authorID = args[2]
+		}
+
+		return exportBlogs(c, args[1], authorID)
+	default:
+		return errors.New(usage)
+	}
+}
+
+// importBlogs streams the blogs from the NDJSON file to the server,
+// a blog per line.
+func importBlogs(c blogpb.BlogServiceClient, path string) error {
+	var r io.Reader = os.Stdin
+
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		r = f
+	}
+
+	// cancels the stream when the file can't be read to its end
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := c.ImportBlogs(ctx)
+	if err != nil {
+		return fmt.Errorf("error while calling ImportBlogs: %w", err)
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+
+	unmarshal := protojson.UnmarshalOptions{DiscardUnknown: true}
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		blog := &blogpb.Blog{}
+		if err = unmarshal.Unmarshal([]byte(text), blog); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if err = stream.Send(&blogpb.ImportBlogsRequest{Blog: blog}); err != nil {
+			// the stream is broken, its status tells why
+			if _, recvErr := stream.CloseAndRecv(); recvErr != nil {
+				err = recvErr
+			}
+
+			return fmt.Errorf("error while sending blog from line %d: %w", line, err)
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return err
+	}
+
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return fmt.Errorf("error while receiving response from ImportBlogs: %w", err)
+	}
+
+	for _, result := range res.GetResults() {
+		if result.GetCode() != 0 {
+			fmt.Fprintf(os.Stderr, "blog #%d %s: %s\n", result.GetIndex(), result.GetBlogId(), result.GetMessage())
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Imported: %d, failed: %d\n", res.GetImported(), res.GetFailed())
+
+	return nil
+}
+
+// exportBlogs writes the blogs of the server to the NDJSON file,
+// a blog per line.
+func exportBlogs(c blogpb.BlogServiceClient, path, authorID string) error {
+	var (
+		w io.Writer = os.Stdout
+		f *os.File
+	)
+
+	if path != "-" {
+		var err error
+
+		f, err = os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		w = f
+	}
+
+	bw := bufio.NewWriter(w)
+
+	stream, err := c.ExportBlogs(context.Background(), &blogpb.ExportBlogsRequest{
+		AuthorId: authorID,
+	})
+	if err != nil {
+		return fmt.Errorf("error while calling ExportBlogs: %w", err)
+	}
+
+	count := 0
+
+	for {
+		res, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			// we've reached the end of file
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		// protojson writes a message in one line when it's not multiline
+		b, err := protojson.Marshal(res.GetBlog())
+		if err != nil {
+			return err
+		}
+
+		if _, err = bw.Write(append(b, '\n')); err != nil {
+			return err
+		}
+
+		count++
+	}
+
+	if err = bw.Flush(); err != nil {
+		return err
+	}
+
+	// the data is written by Close on some file systems
+	if f != nil {
+		if err = f.Close(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported: %d\n", count)
+
+	return nil
+}
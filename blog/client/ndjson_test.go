@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sergeyzalunin/grpc-go-course/blog/blogpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ndjsonClient serves ImportBlogs and ExportBlogs without a server.
+type ndjsonClient struct {
+	blogpb.BlogServiceClient
+	blogs   []*blogpb.Blog
+	sendErr error // of ImportBlogs Send
+	status  error // of ImportBlogs CloseAndRecv
+}
+
+func (c *ndjsonClient) ImportBlogs(context.Context, ...grpc.CallOption) (blogpb.BlogService_ImportBlogsClient, error) {
+	return &importStream{client: c}, nil
+}
+
+func (c *ndjsonClient) ExportBlogs(
+	context.Context,
+	*blogpb.ExportBlogsRequest,
+	...grpc.CallOption,
+) (blogpb.BlogService_ExportBlogsClient, error) {
+	return &exportStream{blogs: c.blogs}, nil
+}
+
+type importStream struct {
+	grpc.ClientStream
+	client *ndjsonClient
+}
+
+func (s *importStream) Send(req *blogpb.ImportBlogsRequest) error {
+	if s.client.sendErr != nil {
+		return s.client.sendErr
+	}
+
+	s.client.blogs = append(s.client.blogs, req.GetBlog())
+
+	return nil
+}
+
+func (s *importStream) CloseAndRecv() (*blogpb.ImportBlogsResponse, error) {
+	if s.client.status != nil {
+		return nil, s.client.status
+	}
+
+	return &blogpb.ImportBlogsResponse{Imported: int32(len(s.client.blogs))}, nil
+}
+
+type exportStream struct {
+	grpc.ClientStream
+	blogs []*blogpb.Blog
+}
+
+func (s *exportStream) Recv() (*blogpb.ExportBlogsResponse, error) {
+	if len(s.blogs) == 0 {
+		return nil, io.EOF
+	}
+
+	blog := s.blogs[0]
+	s.blogs = s.blogs[1:]
+
+	return &blogpb.ExportBlogsResponse{Blog: blog}, nil
+}
+
+func TestExportImportBlogs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blogs.ndjson")
+
+	src := &ndjsonClient{blogs: []*blogpb.Blog{
+		{Id: "1", Title: "first", Content: "a\nb"},
+		{Id: "2", Title: "second"},
+	}}
+
+	if err := exportBlogs(src, path, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := &ndjsonClient{}
+	if err := importBlogs(dst, path); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(dst.blogs) != 2 || dst.blogs[0].GetContent() != "a\nb" || dst.blogs[1].GetTitle() != "second" {
+		t.Errorf("imported %v, want the exported blogs", dst.blogs)
+	}
+}
+
+func TestExportBlogsWriteError(t *testing.T) {
+	if _, err := os.Stat("/dev/full"); err != nil {
+		t.Skip("no /dev/full")
+	}
+
+	// more than the buffer of the writer, so the disk is full before the flush
+	c := &ndjsonClient{}
+	for i := 0; i < 100; i++ {
+		c.blogs = append(c.blogs, &blogpb.Blog{Content: strings.Repeat("x", 1000)})
+	}
+
+	if err := exportBlogs(c, "/dev/full", ""); err == nil {
+		t.Error("exportBlogs() to a full disk returned no error")
+	}
+}
+
+func TestImportBlogsSendError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blogs.ndjson")
+	if err := os.WriteFile(path, []byte(`{"title": "t"}`+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &ndjsonClient{
+		sendErr: io.EOF,
+		status:  status.Error(codes.PermissionDenied, "no import for you"),
+	}
+
+	err := importBlogs(c, path)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("importBlogs() error = %v, want the status of the stream", err)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sergeyzalunin/grpc-go-course/auth"
+)
+
+// roleAdmin lets the caller change the blogs and the comments of the other authors.
+const roleAdmin = "admin"
+
+// errUnauthenticated is returned when the request has no authenticated caller.
+var errUnauthenticated = errors.New("no authenticated caller")
+
+// errNotOwner is returned when the caller changes a blog of another author
+// without the admin role.
+var errNotOwner = errors.New("not the author")
+
+// staticTokens authenticates the tokens listed in a file,
+// by the SHA-256 of the token, so a lookup doesn't leak the tokens by timing.
+// The JWTs of AuthService are used instead when the server has the keys.
+type staticTokens map[[sha256.Size]byte]*auth.Identity
+
+// loadTokens reads the tokens file. Every line is a token, the subject
+// and optionally the comma separated roles, separated by spaces:
+//
+//	s3cr3t-t0ken ninja admin
+//
+// The empty lines and the lines starting with '#' are skipped.
+func loadTokens(path string) (staticTokens, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	tokens := staticTokens{}
+	scanner := bufio.NewScanner(f)
+
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		if len(fields) < 2 || len(fields) > 3 {
+			return nil, fmt.Errorf("%s:%d: want a token, a subject and optional roles", path, line)
+		}
+
+		// the subject becomes the author ID of the blogs
+		if desc := blogRules["author_id"].check(fields[1]); desc != "" {
+			return nil, fmt.Errorf("%s:%d: subject %s", path, line, desc)
+		}
+
+		id := &auth.Identity{Subject: fields[1]}
+		if len(fields) == 3 {
+			id.Roles = strings.Split(fields[2], ",")
+		}
+
+		tokens[sha256.Sum256([]byte(fields[0]))] = id
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+func (t staticTokens) Authenticate(_ context.Context, token string) (*auth.Identity, error) {
+	id, ok := t[sha256.Sum256([]byte(token))]
+	if !ok {
+		return nil, errors.New("unknown token")
+	}
+
+	return id, nil
+}
+
+// caller returns the authenticated caller of the request.
+func caller(ctx context.Context) (*auth.Identity, error) {
+	id, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, errUnauthenticated
+	}
+
+	return id, nil
+}
+
+// checkOwner returns errNotOwner unless the caller is the author or an admin.
+func checkOwner(ctx context.Context, author string) error {
+	p, err := caller(ctx)
+	if err != nil {
+		return err
+	}
+
+	if p.Subject != author && !p.HasRole(roleAdmin) {
+		return errNotOwner
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/sergeyzalunin/grpc-go-course/auth"
+	"github.com/sergeyzalunin/grpc-go-course/blog/blogpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestLoadTokens(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		token   string
+		want    *auth.Identity
+		wantErr bool
+	}{
+		{
+			"roles",
+			"# comment\n\ns3cr3t ninja admin,editor\nother bob\n",
+			"s3cr3t",
+			&auth.Identity{Subject: "ninja", Roles: []string{"admin", "editor"}},
+			false,
+		},
+		{"no roles", "s3cr3t ninja\n", "s3cr3t", &auth.Identity{Subject: "ninja"}, false},
+		{"unknown token", "s3cr3t ninja\n", "other", nil, false},
+		{"no subject", "s3cr3t\n", "", nil, true},
+		{"too many fields", "s3cr3t ninja admin more\n", "", nil, true},
+		{"invalid subject", "s3cr3t ninja/turtle\n", "", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "tokens")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			tokens, err := loadTokens(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadTokens() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			got, err := tokens.Authenticate(context.Background(), tt.token)
+			if (err != nil) != (tt.want == nil) || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Authenticate(%q) = %v, %v, want %v", tt.token, got, err, tt.want)
+			}
+		})
+	}
+
+	if _, err := loadTokens(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("loadTokens() of a missing file succeeded")
+	}
+}
+
+func TestBlogOwnership(t *testing.T) {
+	store := newMemoryStore()
+	s := &server{store: store}
+
+	// the author changes the blog, an admin changes every blog and its author
+	tests := []struct {
+		caller *auth.Identity
+		update codes.Code
+		give   codes.Code
+		delete codes.Code
+	}{
+		{&auth.Identity{Subject: "alice"}, codes.OK, codes.PermissionDenied, codes.OK},
+		{&auth.Identity{Subject: "carol", Roles: []string{roleAdmin}}, codes.OK, codes.OK, codes.OK},
+		{&auth.Identity{Subject: "bob"}, codes.PermissionDenied, codes.PermissionDenied, codes.PermissionDenied},
+		{nil, codes.Unauthenticated, codes.Unauthenticated, codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		name := "anonymous"
+		if tt.caller != nil {
+			name = tt.caller.Subject
+		}
+
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.caller != nil {
+				ctx = auth.NewContext(ctx, tt.caller)
+			}
+
+			blog, err := store.Create(ctx, &blogItem{AuthorID: "alice", Title: "t"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			id := blog.ID.Hex()
+
+			_, err = s.UpdateBlog(ctx, &blogpb.UpdateBlogRequest{
+				Blog:       &blogpb.Blog{Id: id, Title: "updated"},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}},
+			})
+			if status.Code(err) != tt.update {
+				t.Errorf("UpdateBlog() error = %v, want %v", err, tt.update)
+			}
+
+			_, err = s.UpdateBlog(ctx, &blogpb.UpdateBlogRequest{
+				Blog:       &blogpb.Blog{Id: id, AuthorId: "dave"},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"author_id"}},
+			})
+			if status.Code(err) != tt.give {
+				t.Errorf("UpdateBlog() of the author error = %v, want %v", err, tt.give)
+			}
+
+			// an admin deletes the blog given to another author
+			_, err = s.DeleteBlog(ctx, &blogpb.DeleteBlogRequest{BlogId: id})
+			if status.Code(err) != tt.delete {
+				t.Errorf("DeleteBlog() error = %v, want %v", err, tt.delete)
+			}
+		})
+	}
+}
+
+func TestCreateBlogAuthor(t *testing.T) {
+	s := &server{store: newMemoryStore()}
+	ctx := auth.NewContext(context.Background(), &auth.Identity{Subject: "alice"})
+
+	// the author is the caller, not the client supplied author
+	res, err := s.CreateBlog(ctx, &blogpb.CreateBlogRequest{Blog: &blogpb.Blog{AuthorId: "bob", Title: "t"}})
+	if err != nil || res.GetBlog().GetAuthorId() != "alice" {
+		t.Errorf("CreateBlog() = %v, %v, want the blog of alice", res, err)
+	}
+
+	_, err = s.CreateBlog(context.Background(), &blogpb.CreateBlogRequest{Blog: &blogpb.Blog{Title: "t"}})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("CreateBlog() without a caller error = %v, want Unauthenticated", err)
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sergeyzalunin/grpc-go-course/auth"
+	"github.com/sergeyzalunin/grpc-go-course/blog/blogpb"
+	"google.golang.org/grpc/codes"
+)
+
+// resultCodes returns the codes of the batch results.
+func resultCodes(results []*blogpb.BlogResult) []codes.Code {
+	var res []codes.Code
+	for _, r := range results {
+		res = append(res, codes.Code(r.GetCode()))
+	}
+
+	return res
+}
+
+func TestBatchGetBlogs(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+
+	blog, err := store.Create(ctx, &blogItem{AuthorID: "alice", Title: "t"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	missing := testBlogs()[0].ID.Hex()
+
+	res, err := (&server{store: store}).BatchGetBlogs(ctx, &blogpb.BatchGetBlogsRequest{
+		BlogIds: []string{missing, blog.ID.Hex(), "nope", blog.ID.Hex()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []codes.Code{codes.NotFound, codes.OK, codes.InvalidArgument, codes.OK}
+	if got := resultCodes(res.GetResults()); !reflect.DeepEqual(got, want) {
+		t.Errorf("BatchGetBlogs() codes = %v, want %v", got, want)
+	}
+
+	if res.GetResults()[1].GetBlog().GetTitle() != "t" || res.GetResults()[0].GetBlog() != nil {
+		t.Errorf("BatchGetBlogs() results = %v, want the found blog only", res.GetResults())
+	}
+}
+
+func TestBatchDeleteBlogs(t *testing.T) {
+	for _, purge := range []bool{false, true} {
+		ctx := context.Background()
+		store := newMemoryStore()
+
+		var ids []string
+
+		for _, author := range []string{"alice", "bob", "alice"} {
+			blog, err := store.Create(ctx, &blogItem{AuthorID: author, Title: "t"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids = append(ids, blog.ID.Hex())
+		}
+
+		ctx = auth.NewContext(ctx, &auth.Identity{Subject: "alice"})
+
+		res, err := (&server{store: store}).BatchDeleteBlogs(ctx, &blogpb.BatchDeleteBlogsRequest{
+			BlogIds: []string{ids[0], ids[1], testBlogs()[0].ID.Hex(), ids[2]},
+			Purge:   purge,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []codes.Code{codes.OK, codes.PermissionDenied, codes.NotFound, codes.OK}
+		if got := resultCodes(res.GetResults()); !reflect.DeepEqual(got, want) {
+			t.Errorf("purge %v: BatchDeleteBlogs() codes = %v, want %v", purge, got, want)
+		}
+
+		if res.GetDeleted() != 2 || res.GetFailed() != 2 {
+			t.Errorf("purge %v: deleted %d, failed %d, want 2 and 2", purge, res.GetDeleted(), res.GetFailed())
+		}
+
+		// a soft deleted blog is in the trash, a purged one is gone
+		oid, _ := parseObjectID("id", ids[0])
+
+		if _, err = store.Undelete(ctx, oid); errors.Is(err, errBlogNotFound) != purge {
+			t.Errorf("purge %v: Undelete() of a deleted blog error = %v", purge, err)
+		}
+
+		oid, _ = parseObjectID("id", ids[1])
+
+		if _, err = store.Read(ctx, oid); err != nil {
+			t.Errorf("purge %v: the blog of another author is deleted: %v", purge, err)
+		}
+	}
+}
+
+func TestDeleteBlogID(t *testing.T) {
+	ctx := auth.NewContext(context.Background(), &auth.Identity{Subject: "alice"})
+	store := newMemoryStore()
+	s := &server{store: store}
+
+	tests := []struct {
+		name string
+		req  func(id string) *blogpb.DeleteBlogRequest
+	}{
+		{"blog_id", func(id string) *blogpb.DeleteBlogRequest {
+			return &blogpb.DeleteBlogRequest{BlogId: id}
+		}},
+		// the old clients set the whole blog
+		{"blog.id", func(id string) *blogpb.DeleteBlogRequest {
+			return &blogpb.DeleteBlogRequest{Blog: &blogpb.Blog{Id: id}} //nolint
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blog, err := store.Create(ctx, &blogItem{AuthorID: "alice", Title: "t"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := s.DeleteBlog(ctx, tt.req(blog.ID.Hex()))
+			if err != nil || res.GetBlogId() != blog.ID.Hex() {
+				t.Fatalf("DeleteBlog() = %v, %v", res, err)
+			}
+
+			if _, err = store.Read(ctx, blog.ID); !errors.Is(err, errBlogNotFound) {
+				t.Errorf("Read() of a deleted blog error = %v", err)
+			}
+		})
+	}
+}
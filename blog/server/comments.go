@@ -0,0 +1,568 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sergeyzalunin/grpc-go-course/blog/blogpb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errCommentNotFound is returned by a CommentStore
+// when there is no comment with the requested ID.
+var errCommentNotFound = errors.New("comment not found")
+
+// commentState is a moderation state of a comment.
+type commentState int
+
+const (
+	commentPending commentState = iota
+	commentApproved
+	commentRejected
+)
+
+type commentItem struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	BlogID     primitive.ObjectID `bson:"blog_id"`
+	ParentID   primitive.ObjectID `bson:"parent_id,omitempty"` // zero for a top level comment
+	AuthorID   string             `bson:"author_id"`
+	Content    string             `bson:"content"`
+	State      commentState       `bson:"state"`
+	CreateTime time.Time          `bson:"create_time"`
+	UpdateTime time.Time          `bson:"update_time"`
+}
+
+// newCommentData returns the created comment with a new ID and the times set to now.
+func newCommentData(item *commentItem) commentItem {
+	data := *item
+	data.ID = primitive.NewObjectID()
+	data.CreateTime = storeNow()
+	data.UpdateTime = data.CreateTime
+
+	return data
+}
+
+// commentQuery selects the comments returned by CommentStore.ListComments.
+// The comments are ordered by their IDs, which is the creation order.
+type commentQuery struct {
+	BlogID primitive.ObjectID
+	States []commentState      // any state when empty
+	After  *primitive.ObjectID // skip the comments up to and including this one
+	Limit  int                 // 0 means no limit
+}
+
+// match reports whether the item is selected by the query.
+func (q *commentQuery) match(item *commentItem) bool {
+	if item.BlogID != q.BlogID {
+		return false
+	}
+
+	if q.After != nil && item.ID.Hex() <= q.After.Hex() {
+		return false
+	}
+
+	if len(q.States) == 0 {
+		return true
+	}
+
+	for _, state := range q.States {
+		if item.State == state {
+			return true
+		}
+	}
+
+	return false
+}
+
+// digest identifies the blog and the states of the query.
+func (q *commentQuery) digest() string {
+	b, err := json.Marshal(struct {
+		BlogID string
+		States []commentState
+	}{q.BlogID.Hex(), q.States})
+	if err != nil {
+		// can't happen, the query has only strings and numbers
+		panic(err)
+	}
+
+	sum := sha256.Sum256(b)
+
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+// commentPageToken is the content of the opaque page_token of the comments.
+type commentPageToken struct {
+	Query string `json:"q"`
+	After string `json:"a"`
+}
+
+// commentQueryFromPb converts the request into a store query.
+// Without the states in the request only the approved comments are selected,
+// unless the caller moderates the blog. Limit is left for the caller to set.
+func commentQueryFromPb(req *blogpb.ListCommentsRequest, moderator bool) (commentQuery, error) {
+	var q commentQuery
+
+	oid, err := parseObjectID("blog_id", req.GetBlogId())
+	if err != nil {
+		return q, err
+	}
+
+	q.BlogID = oid
+
+	for _, state := range req.GetStates() {
+		s, err := commentStateFromPb(state)
+		if err != nil {
+			return q, &fieldError{"states", err}
+		}
+
+		q.States = append(q.States, s)
+	}
+
+	if len(q.States) == 0 && !moderator {
+		q.States = []commentState{commentApproved}
+	}
+
+	if req.GetPageToken() == "" {
+		return q, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(req.GetPageToken())
+	if err != nil {
+		return q, errBadPageToken
+	}
+
+	var token commentPageToken
+	if err = json.Unmarshal(b, &token); err != nil {
+		return q, errBadPageToken
+	}
+
+	if token.Query != q.digest() {
+		return q, &fieldError{"page_token", errors.New("doesn't match the request")}
+	}
+
+	after, err := primitive.ObjectIDFromHex(token.After)
+	if err != nil {
+		return q, errBadPageToken
+	}
+
+	q.After = &after
+
+	return q, nil
+}
+
+// nextCommentPageToken returns the token of the page which starts after the item.
+func nextCommentPageToken(q commentQuery, last *commentItem) string {
+	b, err := json.Marshal(commentPageToken{
+		Query: q.digest(),
+		After: last.ID.Hex(),
+	})
+	if err != nil {
+		// can't happen, the token has only strings
+		panic(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func commentStateFromPb(state blogpb.Comment_State) (commentState, error) {
+	switch state {
+	case blogpb.Comment_PENDING:
+		return commentPending, nil
+	case blogpb.Comment_APPROVED:
+		return commentApproved, nil
+	case blogpb.Comment_REJECTED:
+		return commentRejected, nil
+	default:
+		return 0, errors.New("has an unknown value")
+	}
+}
+
+func commentToPb(data *commentItem) *blogpb.Comment {
+	state := blogpb.Comment_PENDING
+
+	switch data.State {
+	case commentApproved:
+		state = blogpb.Comment_APPROVED
+	case commentRejected:
+		state = blogpb.Comment_REJECTED
+	case commentPending:
+	}
+
+	parentID := ""
+	if !data.ParentID.IsZero() {
+		parentID = data.ParentID.Hex()
+	}
+
+	return &blogpb.Comment{
+		Id:         data.ID.Hex(),
+		BlogId:     data.BlogID.Hex(),
+		ParentId:   parentID,
+		AuthorId:   data.AuthorID,
+		Content:    data.Content,
+		State:      state,
+		CreateTime: timeToPb(data.CreateTime),
+		UpdateTime: timeToPb(data.UpdateTime),
+	}
+}
+
+// commentServer serves the comments of the blogs kept in the store.
+type commentServer struct {
+	store Store
+}
+
+func (s *commentServer) CreateComment(
+	ctx context.Context,
+	req *blogpb.CreateCommentRequest,
+) (*blogpb.CreateCommentResponse, error) {
+	fmt.Println("Create comment")
+
+	comment := req.GetComment()
+
+	// the caller is the author of the comment
+	p, err := caller(ctx)
+	if err != nil {
+		return nil, storeError(err, "")
+	}
+
+	blogID, err := parseObjectID("comment.blog_id", comment.GetBlogId())
+	if err != nil {
+		return nil, err
+	}
+
+	// a deleted blog can't be commented
+	if _, err = s.store.Read(ctx, blogID); err != nil {
+		return nil, storeError(err, comment.GetBlogId())
+	}
+
+	data := &commentItem{
+		BlogID:   blogID,
+		AuthorID: p.Subject,
+		Content:  comment.GetContent(),
+		State:    commentPending,
+	}
+
+	if comment.GetParentId() != "" {
+		data.ParentID, err = parseObjectID("comment.parent_id", comment.GetParentId())
+		if err != nil {
+			return nil, err
+		}
+
+		parent, err := s.store.ReadComment(ctx, data.ParentID)
+		if err != nil {
+			return nil, storeError(err, comment.GetParentId())
+		}
+
+		if parent.BlogID != blogID {
+			return nil, badRequest("comment.parent_id", "is a comment of another blog")
+		}
+	}
+
+	data, err = s.store.CreateComment(ctx, data)
+	if err != nil {
+		return nil, storeError(err, "")
+	}
+
+	return &blogpb.CreateCommentResponse{
+		Comment: commentToPb(data),
+	}, nil
+}
+
+func (s *commentServer) ListComments(
+	req *blogpb.ListCommentsRequest,
+	stream blogpb.CommentService_ListCommentsServer,
+) error {
+	fmt.Println("List of comments")
+
+	q, err := s.commentQuery(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	send := func(data *commentItem, nextPageToken string) error {
+		err := stream.Send(&blogpb.ListCommentsResponse{
+			Comment:       commentToPb(data),
+			NextPageToken: nextPageToken,
+		})
+		if err != nil {
+			return status.Errorf(
+				codes.Internal,
+				"Error while sending data: %v",
+				err,
+			)
+		}
+
+		return nil
+	}
+
+	if req.GetPageSize() <= 0 {
+		// stream all the comments as they are read
+		err = s.store.ListComments(stream.Context(), q, func(data *commentItem) error {
+			return send(data, "")
+		})
+
+		return storeError(err, req.GetBlogId())
+	}
+
+	items, nextPageToken, err := s.listPage(stream.Context(), q, int(req.GetPageSize()))
+	if err != nil {
+		return storeError(err, req.GetBlogId())
+	}
+
+	for i, data := range items {
+		token := ""
+		if i == len(items)-1 {
+			token = nextPageToken
+		}
+
+		if err = send(data, token); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *commentServer) ListCommentsPage(
+	ctx context.Context,
+	req *blogpb.ListCommentsRequest,
+) (*blogpb.ListCommentsPageResponse, error) {
+	fmt.Println("Page of comments")
+
+	q, err := s.commentQuery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	items, nextPageToken, err := s.listPage(ctx, q, pageSize)
+	if err != nil {
+		return nil, storeError(err, req.GetBlogId())
+	}
+
+	res := &blogpb.ListCommentsPageResponse{
+		Comments:      make([]*blogpb.Comment, 0, len(items)),
+		NextPageToken: nextPageToken,
+	}
+
+	for _, data := range items {
+		res.Comments = append(res.Comments, commentToPb(data))
+	}
+
+	return res, nil
+}
+
+// commentQuery converts the request into a store query of the comments
+// the caller can read. The pending and rejected comments are read
+// only by the author of the blog and the admins, who moderate them.
+func (s *commentServer) commentQuery(ctx context.Context, req *blogpb.ListCommentsRequest) (commentQuery, error) {
+	blogID, err := parseObjectID("blog_id", req.GetBlogId())
+	if err != nil {
+		return commentQuery{}, err
+	}
+
+	blog, err := s.store.Read(ctx, blogID)
+	if err != nil {
+		return commentQuery{}, storeError(err, req.GetBlogId())
+	}
+
+	moderator := true
+
+	if err = checkOwner(ctx, blog.AuthorID); errors.Is(err, errNotOwner) {
+		moderator = false
+	} else if err != nil {
+		return commentQuery{}, storeError(err, req.GetBlogId())
+	}
+
+	q, err := commentQueryFromPb(req, moderator)
+	if err != nil {
+		return q, storeError(err, "")
+	}
+
+	for _, state := range q.States {
+		if !moderator && state != commentApproved {
+			return q, resourceError(
+				codes.PermissionDenied,
+				blogResource,
+				req.GetBlogId(),
+				fmt.Sprintf("Only the author of blog %s or an admin can list its pending and rejected comments", req.GetBlogId()),
+			)
+		}
+	}
+
+	return q, nil
+}
+
+// listPage reads one page of the comments selected by the query
+// and returns it with the token of the next page.
+func (s *commentServer) listPage(
+	ctx context.Context,
+	q commentQuery,
+	pageSize int,
+) ([]*commentItem, string, error) {
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	// read one more comment to know whether there is a next page
+	q.Limit = pageSize + 1
+	items := make([]*commentItem, 0, q.Limit)
+
+	err := s.store.ListComments(ctx, q, func(data *commentItem) error {
+		items = append(items, data)
+
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(items) <= pageSize {
+		return items, "", nil
+	}
+
+	items = items[:pageSize]
+
+	return items, nextCommentPageToken(q, items[pageSize-1]), nil
+}
+
+func (s *commentServer) EditComment(
+	ctx context.Context,
+	req *blogpb.EditCommentRequest,
+) (*blogpb.EditCommentResponse, error) {
+	fmt.Println("Edit comment")
+
+	data, err := s.readComment(ctx, req.GetCommentId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = authorizeComment(ctx, data, data.AuthorID); err != nil {
+		return nil, err
+	}
+
+	// the new content is moderated again
+	data.Content = req.GetContent()
+	data.State = commentPending
+
+	data, err = s.store.UpdateComment(ctx, data)
+	if err != nil {
+		return nil, storeError(err, req.GetCommentId())
+	}
+
+	return &blogpb.EditCommentResponse{
+		Comment: commentToPb(data),
+	}, nil
+}
+
+func (s *commentServer) DeleteComment(
+	ctx context.Context,
+	req *blogpb.DeleteCommentRequest,
+) (*blogpb.DeleteCommentResponse, error) {
+	fmt.Println("Delete comment")
+
+	data, err := s.readComment(ctx, req.GetCommentId())
+	if err != nil {
+		return nil, err
+	}
+
+	// the author of the blog deletes the comments as well
+	blogAuthor, err := s.store.Author(ctx, data.BlogID)
+	if err != nil {
+		return nil, storeError(err, data.BlogID.Hex())
+	}
+
+	if err = authorizeComment(ctx, data, data.AuthorID, blogAuthor); err != nil {
+		return nil, err
+	}
+
+	n, err := s.store.DeleteComment(ctx, data.ID)
+	if err != nil {
+		return nil, storeError(err, req.GetCommentId())
+	}
+
+	return &blogpb.DeleteCommentResponse{
+		CommentId: req.GetCommentId(),
+		Deleted:   int32(n),
+	}, nil
+}
+
+func (s *commentServer) ModerateComment(
+	ctx context.Context,
+	req *blogpb.ModerateCommentRequest,
+) (*blogpb.ModerateCommentResponse, error) {
+	fmt.Println("Moderate comment")
+
+	state, err := commentStateFromPb(req.GetState())
+	if err != nil {
+		return nil, badRequest("state", err.Error())
+	}
+
+	data, err := s.readComment(ctx, req.GetCommentId())
+	if err != nil {
+		return nil, err
+	}
+
+	// the comments are moderated by the author of the blog
+	blogAuthor, err := s.store.Author(ctx, data.BlogID)
+	if err != nil {
+		return nil, storeError(err, data.BlogID.Hex())
+	}
+
+	if err = authorizeComment(ctx, data, blogAuthor); err != nil {
+		return nil, err
+	}
+
+	data.State = state
+
+	data, err = s.store.UpdateComment(ctx, data)
+	if err != nil {
+		return nil, storeError(err, req.GetCommentId())
+	}
+
+	return &blogpb.ModerateCommentResponse{
+		Comment: commentToPb(data),
+	}, nil
+}
+
+// readComment reads the comment by the comment_id of a request.
+func (s *commentServer) readComment(ctx context.Context, commentID string) (*commentItem, error) {
+	oid, err := parseObjectID("comment_id", commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.store.ReadComment(ctx, oid)
+	if err != nil {
+		return nil, storeError(err, commentID)
+	}
+
+	return data, nil
+}
+
+// authorizeComment returns PermissionDenied unless the caller
+// is one of the authors allowed to change the comment or an admin.
+func authorizeComment(ctx context.Context, data *commentItem, authors ...string) error {
+	for _, author := range authors {
+		if err := checkOwner(ctx, author); !errors.Is(err, errNotOwner) {
+			return storeError(err, data.ID.Hex())
+		}
+	}
+
+	return resourceError(
+		codes.PermissionDenied,
+		commentResource,
+		data.ID.Hex(),
+		fmt.Sprintf("Comment %s can't be changed by the caller", data.ID.Hex()),
+	)
+}
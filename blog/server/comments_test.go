@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sergeyzalunin/grpc-go-course/auth"
+	"github.com/sergeyzalunin/grpc-go-course/blog/blogpb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListCommentsStates(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+
+	blog, err := store.Create(ctx, &blogItem{AuthorID: "alice", Title: "t"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		content string
+		state   commentState
+	}{
+		{"pending", commentPending},
+		{"approved", commentApproved},
+		{"rejected", commentRejected},
+		{"approved too", commentApproved},
+	} {
+		_, err = store.CreateComment(ctx, &commentItem{BlogID: blog.ID, AuthorID: "dave", Content: c.content, State: c.state})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	all := []string{"pending", "approved", "rejected", "approved too"}
+	approved := []string{"approved", "approved too"}
+
+	tests := []struct {
+		name   string
+		caller *auth.Identity
+		states []blogpb.Comment_State
+		want   []string
+		code   codes.Code
+	}{
+		{"author", &auth.Identity{Subject: "alice"}, nil, all, codes.OK},
+		{"admin", &auth.Identity{Subject: "carol", Roles: []string{roleAdmin}}, nil, all, codes.OK},
+		{"other", &auth.Identity{Subject: "bob"}, nil, approved, codes.OK},
+		{"commenter", &auth.Identity{Subject: "dave"}, nil, approved, codes.OK},
+		{"other approved", &auth.Identity{Subject: "bob"}, []blogpb.Comment_State{blogpb.Comment_APPROVED}, approved, codes.OK},
+		{
+			"author pending",
+			&auth.Identity{Subject: "alice"},
+			[]blogpb.Comment_State{blogpb.Comment_PENDING},
+			[]string{"pending"},
+			codes.OK,
+		},
+		{"other pending", &auth.Identity{Subject: "bob"}, []blogpb.Comment_State{blogpb.Comment_PENDING}, nil, codes.PermissionDenied},
+		{
+			"other rejected",
+			&auth.Identity{Subject: "bob"},
+			[]blogpb.Comment_State{blogpb.Comment_APPROVED, blogpb.Comment_REJECTED},
+			nil,
+			codes.PermissionDenied,
+		},
+		{"anonymous", nil, nil, nil, codes.Unauthenticated},
+	}
+
+	s := &commentServer{store: store}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.caller != nil {
+				ctx = auth.NewContext(ctx, tt.caller)
+			}
+
+			// pages of one comment check that the page tokens keep the states
+			req := &blogpb.ListCommentsRequest{BlogId: blog.ID.Hex(), PageSize: 1, States: tt.states}
+
+			var got []string
+
+			for {
+				res, err := s.ListCommentsPage(ctx, req)
+				if status.Code(err) != tt.code {
+					t.Fatalf("ListCommentsPage() error = %v, want %v", err, tt.code)
+				}
+
+				if err != nil {
+					return
+				}
+
+				for _, c := range res.GetComments() {
+					got = append(got, c.GetContent())
+				}
+
+				if res.GetNextPageToken() == "" {
+					break
+				}
+
+				req.PageToken = res.GetNextPageToken()
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListCommentsPage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStoreDeleteComment(t *testing.T) {
+	ctx := context.Background()
+
+	for name, s := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			blog, err := s.Create(ctx, &blogItem{AuthorID: "alice", Title: "t"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// root <- reply <- reply of reply, and another root
+			var (
+				parent primitive.ObjectID
+				ids    []primitive.ObjectID
+			)
+
+			for _, content := range []string{"root", "reply", "reply of reply"} {
+				c, err := s.CreateComment(ctx, &commentItem{BlogID: blog.ID, ParentID: parent, AuthorID: "bob", Content: content})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				parent = c.ID
+				ids = append(ids, c.ID)
+			}
+
+			other, err := s.CreateComment(ctx, &commentItem{BlogID: blog.ID, AuthorID: "bob", Content: "other"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			n, err := s.DeleteComment(ctx, ids[1])
+			if err != nil || n != 2 {
+				t.Fatalf("DeleteComment() = %d, %v, want the reply with its reply", n, err)
+			}
+
+			if _, err = s.ReadComment(ctx, ids[2]); !errors.Is(err, errCommentNotFound) {
+				t.Errorf("ReadComment() of a deleted reply error = %v", err)
+			}
+
+			if _, err = s.DeleteComment(ctx, ids[1]); !errors.Is(err, errCommentNotFound) {
+				t.Errorf("DeleteComment() of a deleted comment error = %v", err)
+			}
+
+			// the comments are removed with their blog
+			if _, err = s.Delete(ctx, blog.ID, 0); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, id := range []primitive.ObjectID{ids[0], other.ID} {
+				if _, err = s.ReadComment(ctx, id); !errors.Is(err, errCommentNotFound) {
+					t.Errorf("ReadComment() of a comment of a deleted blog error = %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestCommentAuthorization(t *testing.T) {
+	store := newMemoryStore()
+	s := &commentServer{store: store}
+
+	blog, err := store.Create(context.Background(), &blogItem{AuthorID: "alice", Title: "t"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the commenter edits the comment, the author of the blog moderates it,
+	// both delete it; an admin does everything
+	tests := []struct {
+		caller   string
+		roles    []string
+		edit     codes.Code
+		moderate codes.Code
+		delete   codes.Code
+	}{
+		{"dave", nil, codes.OK, codes.PermissionDenied, codes.OK},
+		{"alice", nil, codes.PermissionDenied, codes.OK, codes.OK},
+		{"carol", []string{roleAdmin}, codes.OK, codes.OK, codes.OK},
+		{"bob", nil, codes.PermissionDenied, codes.PermissionDenied, codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.caller, func(t *testing.T) {
+			ctx := auth.NewContext(context.Background(), &auth.Identity{Subject: tt.caller, Roles: tt.roles})
+
+			comment, err := store.CreateComment(ctx, &commentItem{BlogID: blog.ID, AuthorID: "dave", Content: "c"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			id := comment.ID.Hex()
+
+			_, err = s.EditComment(ctx, &blogpb.EditCommentRequest{CommentId: id, Content: "edited"})
+			if status.Code(err) != tt.edit {
+				t.Errorf("EditComment() error = %v, want %v", err, tt.edit)
+			}
+
+			_, err = s.ModerateComment(ctx, &blogpb.ModerateCommentRequest{CommentId: id, State: blogpb.Comment_APPROVED})
+			if status.Code(err) != tt.moderate {
+				t.Errorf("ModerateComment() error = %v, want %v", err, tt.moderate)
+			}
+
+			_, err = s.DeleteComment(ctx, &blogpb.DeleteCommentRequest{CommentId: id})
+			if status.Code(err) != tt.delete {
+				t.Errorf("DeleteComment() error = %v, want %v", err, tt.delete)
+			}
+		})
+	}
+}
+
+func TestModerateComment(t *testing.T) {
+	ctx := auth.NewContext(context.Background(), &auth.Identity{Subject: "alice"})
+	store := newMemoryStore()
+	s := &commentServer{store: store}
+
+	blog, err := store.Create(ctx, &blogItem{AuthorID: "alice", Title: "t"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a comment of the author of the blog, who both edits and moderates it
+	comment, err := store.CreateComment(ctx, &commentItem{BlogID: blog.ID, AuthorID: "alice", Content: "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := comment.ID.Hex()
+
+	res, err := s.ModerateComment(ctx, &blogpb.ModerateCommentRequest{CommentId: id, State: blogpb.Comment_APPROVED})
+	if err != nil || res.GetComment().GetState() != blogpb.Comment_APPROVED {
+		t.Fatalf("ModerateComment() = %v, %v, want an approved comment", res, err)
+	}
+
+	_, err = s.ModerateComment(ctx, &blogpb.ModerateCommentRequest{CommentId: id, State: blogpb.Comment_State(7)})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ModerateComment() of an unknown state error = %v, want InvalidArgument", err)
+	}
+
+	// the edited content is moderated again
+	edited, err := s.EditComment(ctx, &blogpb.EditCommentRequest{CommentId: id, Content: "edited"})
+	if err != nil || edited.GetComment().GetState() != blogpb.Comment_PENDING {
+		t.Errorf("EditComment() = %v, %v, want a pending comment", edited, err)
+	}
+
+	_, err = s.ModerateComment(ctx, &blogpb.ModerateCommentRequest{CommentId: primitive.NewObjectID().Hex()})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("ModerateComment() of a missing comment error = %v, want NotFound", err)
+	}
+}
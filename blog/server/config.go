@@ -0,0 +1,71 @@
+package main
+
+import (
+	"time"
+
+	"github.com/sergeyzalunin/grpc-go-course/config"
+	"github.com/sergeyzalunin/grpc-go-course/serverkit"
+)
+
+// envPrefix is the prefix of the environment variables of the server config.
+const envPrefix = "BLOG_SERVER_"
+
+// serverConfig is the config of the server loaded by the config package.
+type serverConfig struct {
+	Server serverkit.Config `yaml:",inline"`
+
+	Store  string `yaml:"store" help:"blog storage backend: mongo, sqlite or memory"`
+	SQLite string `yaml:"sqlite" help:"SQLite database file for the sqlite store"`
+	Mongo  struct {
+		URI      string        `yaml:"uri" help:"MongoDB URI without the credentials"`
+		Username string        `yaml:"username" help:"MongoDB user, no authentication when empty"`
+		Password config.Secret `yaml:"password" help:"password of the MongoDB user"`
+	} `yaml:"mongo"`
+
+	TrashRetention time.Duration `yaml:"trash_retention" help:"how long deleted blogs are kept, 0 keeps them forever"`
+	SweepInterval  time.Duration `yaml:"sweep_interval" help:"how often deleted blogs are purged"`
+
+	Tokens   string `yaml:"tokens" help:"file of the bearer tokens of the callers"`
+	AuthKeys string `yaml:"auth_keys" help:"directory of the AuthService token keys, the tokens file is used when empty"`
+}
+
+func defaultServerConfig() *serverConfig {
+	cfg := &serverConfig{
+		Server:         serverkit.DefaultConfig("blog", "localhost:50052"),
+		Store:          "mongo",
+		SQLite:         "blog.db",
+		TrashRetention: defaultTrashRetention,
+		SweepInterval:  defaultSweepInterval,
+		Tokens:         "tokens.txt",
+	}
+	cfg.Server.TLS.Enabled = true
+	cfg.Mongo.URI = "mongodb://172.28.1.3:27017"
+	cfg.Mongo.Username = "root"
+
+	return cfg
+}
+
+func (c *serverConfig) Validate(check *config.Check) {
+	c.Server.Validate(check)
+	check.OneOf("store", c.Store, "mongo", "sqlite", "memory")
+
+	if c.Store == "mongo" && c.Mongo.Username != "" && c.Mongo.Password.Value() == "" {
+		check.Errorf("mongo.password", "is required for the user %s, set %sMONGO_PASSWORD or %sMONGO_PASSWORD_FILE",
+			c.Mongo.Username, envPrefix, envPrefix)
+	}
+
+	if c.TrashRetention < 0 {
+		check.Errorf("trash_retention", "must not be negative")
+	}
+
+	if c.TrashRetention > 0 && c.SweepInterval <= 0 {
+		check.Errorf("sweep_interval", "must be positive")
+	}
+
+	check.File("auth_keys", c.AuthKeys)
+
+	// the client certificates identify the callers instead of the tokens
+	if c.Server.TLS.ClientCAFile == "" && c.AuthKeys == "" {
+		check.File("tokens", c.Tokens)
+	}
+}
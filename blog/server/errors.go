@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ResourceInfo types of the blogs and the comments.
+const (
+	blogResource    = "blog"
+	commentResource = "comment"
+)
+
+// fieldError is an invalid field of a request.
+type fieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *fieldError) Error() string {
+	return e.Field + " " + e.Err.Error()
+}
+
+func (e *fieldError) Unwrap() error {
+	return e.Err
+}
+
+// storeError converts an error of BlogStore, or of the request it serves,
+// to a status error with the google.rpc error details.
+// The ID names the blog, or the comment for errCommentNotFound,
+// in the details, it may be empty.
+// Status errors are returned as they are.
+func storeError(err error, id string) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var fieldErr *fieldError
+
+	switch {
+	case errors.As(err, &fieldErr):
+		return badRequest(fieldErr.Field, fieldErr.Err.Error())
+	case errors.Is(err, errBadPageToken):
+		return badRequest("page_token", "is invalid")
+	case errors.Is(err, errBadResumeToken):
+		return badRequest("resume_token", "is invalid")
+	case errors.Is(err, errResumeTokenExpired):
+		return status.Errorf(
+			codes.OutOfRange,
+			"Cannot resume the watch, read the blogs with ListBlog and watch again: %v",
+			err,
+		)
+	case errors.Is(err, errBlogNotFound):
+		return resourceError(
+			codes.NotFound,
+			blogResource,
+			id,
+			fmt.Sprintf("There is no blog with id: %s", id),
+		)
+	case errors.Is(err, errCommentNotFound):
+		return resourceError(
+			codes.NotFound,
+			commentResource,
+			id,
+			fmt.Sprintf("There is no comment with id: %s", id),
+		)
+	case errors.Is(err, errBlogExists):
+		return resourceError(
+			codes.AlreadyExists,
+			blogResource,
+			id,
+			fmt.Sprintf("There is a blog with id: %s", id),
+		)
+	case errors.Is(err, errUnauthenticated):
+		return status.Error(codes.Unauthenticated, "The request has no authenticated caller")
+	case errors.Is(err, errNotOwner):
+		return resourceError(
+			codes.PermissionDenied,
+			blogResource,
+			id,
+			fmt.Sprintf("Only the author of blog %s or an admin can change it", id),
+		)
+	case errors.Is(err, errVersionMismatch):
+		return resourceError(
+			codes.Aborted,
+			blogResource,
+			id,
+			fmt.Sprintf("Blog %s was changed since the expected version, read it again and retry", id),
+		)
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, errStoreTimeout):
+		return status.Errorf(
+			codes.DeadlineExceeded,
+			"Storage timed out: %v",
+			err,
+		)
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "The client canceled the request")
+	default:
+		return status.Errorf(
+			codes.Internal,
+			"Something went wrong: %v",
+			err,
+		)
+	}
+}
+
+// badRequest returns InvalidArgument with the violation of the request field.
+func badRequest(field, description string) error {
+	return fieldViolations([]*errdetails.BadRequest_FieldViolation{{
+		Field:       field,
+		Description: description,
+	}})
+}
+
+// fieldViolations returns InvalidArgument with the violations of the request fields.
+func fieldViolations(violations []*errdetails.BadRequest_FieldViolation) error {
+	msgs := make([]string, 0, len(violations))
+	for _, v := range violations {
+		msgs = append(msgs, v.GetField()+" "+v.GetDescription())
+	}
+
+	st := status.Newf(
+		codes.InvalidArgument,
+		"Invalid request: %s",
+		strings.Join(msgs, "; "),
+	)
+
+	ds, err := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: violations,
+	})
+	if err != nil {
+		// can't happen, the details are proto messages
+		return st.Err()
+	}
+
+	return ds.Err()
+}
+
+// resourceError returns the error about the resource with ResourceInfo.
+func resourceError(code codes.Code, resourceType, name, msg string) error {
+	st := status.New(code, msg)
+
+	ds, err := st.WithDetails(&errdetails.ResourceInfo{
+		ResourceType: resourceType,
+		ResourceName: name,
+		Description:  msg,
+	})
+	if err != nil {
+		// can't happen, the details are proto messages
+		return st.Err()
+	}
+
+	return ds.Err()
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStoreError(t *testing.T) {
+	const id = "5f1d7a3b2c9e4a0012345678"
+
+	tests := []struct {
+		name     string
+		err      error
+		code     codes.Code
+		resource string // the ResourceType of the ResourceInfo detail
+		field    string // the field of the BadRequest detail
+	}{
+		{"not found", errBlogNotFound, codes.NotFound, blogResource, ""},
+		{"wrapped not found", fmt.Errorf("read: %w", errBlogNotFound), codes.NotFound, blogResource, ""},
+		{"comment not found", errCommentNotFound, codes.NotFound, commentResource, ""},
+		{"exists", errBlogExists, codes.AlreadyExists, blogResource, ""},
+		{"not owner", errNotOwner, codes.PermissionDenied, blogResource, ""},
+		{"version mismatch", errVersionMismatch, codes.Aborted, blogResource, ""},
+		{"unauthenticated", errUnauthenticated, codes.Unauthenticated, "", ""},
+		{"field", &fieldError{"title", errors.New("is required")}, codes.InvalidArgument, "", "title"},
+		{"page token", errBadPageToken, codes.InvalidArgument, "", "page_token"},
+		{"resume token", errBadResumeToken, codes.InvalidArgument, "", "resume_token"},
+		{"resume token expired", errResumeTokenExpired, codes.OutOfRange, "", ""},
+		{"store timeout", fmt.Errorf("%w: slow", errStoreTimeout), codes.DeadlineExceeded, "", ""},
+		{"deadline", context.DeadlineExceeded, codes.DeadlineExceeded, "", ""},
+		{"canceled", context.Canceled, codes.Canceled, "", ""},
+		{"unknown", errors.New("boom"), codes.Internal, "", ""},
+		{"status kept", status.Error(codes.Unavailable, "down"), codes.Unavailable, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(storeError(tt.err, id))
+			if st.Code() != tt.code {
+				t.Errorf("code = %v, want %v", st.Code(), tt.code)
+			}
+
+			var (
+				resource *errdetails.ResourceInfo
+				request  *errdetails.BadRequest
+			)
+
+			for _, d := range st.Details() {
+				switch d := d.(type) {
+				case *errdetails.ResourceInfo:
+					resource = d
+				case *errdetails.BadRequest:
+					request = d
+				}
+			}
+
+			switch {
+			case tt.resource == "" && resource != nil:
+				t.Errorf("unexpected ResourceInfo %v", resource)
+			case tt.resource != "" && resource == nil:
+				t.Errorf("no ResourceInfo of %s", tt.resource)
+			case tt.resource != "" && (resource.GetResourceType() != tt.resource || resource.GetResourceName() != id):
+				t.Errorf("ResourceInfo = %v, want %s %s", resource, tt.resource, id)
+			}
+
+			switch {
+			case tt.field == "" && request != nil:
+				t.Errorf("unexpected BadRequest %v", request)
+			case tt.field != "" && request == nil:
+				t.Errorf("no BadRequest of %s", tt.field)
+			case tt.field != "" && request.GetFieldViolations()[0].GetField() != tt.field:
+				t.Errorf("BadRequest = %v, want a violation of %s", request, tt.field)
+			}
+		})
+	}
+
+	if err := storeError(nil, id); err != nil {
+		t.Errorf("storeError(nil) = %v, want nil", err)
+	}
+}
+
+func TestFieldViolations(t *testing.T) {
+	err := fieldViolations([]*errdetails.BadRequest_FieldViolation{
+		{Field: "title", Description: "is required"},
+		{Field: "content", Description: "is too long"},
+	})
+
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Errorf("code = %v, want InvalidArgument", st.Code())
+	}
+
+	if want := "Invalid request: title is required; content is too long"; st.Message() != want {
+		t.Errorf("message = %q, want %q", st.Message(), want)
+	}
+
+	if br, ok := st.Details()[0].(*errdetails.BadRequest); !ok || len(br.GetFieldViolations()) != 2 {
+		t.Errorf("details = %v, want both violations", st.Details())
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// eventBufferSize is the number of the last events kept by eventBus
+// for the watchers which resume after a reconnect.
+const eventBufferSize = 1024
+
+var (
+	errBadResumeToken     = errors.New("invalid resume token")
+	errResumeTokenExpired = errors.New("resume token expired")
+)
+
+// eventType is a kind of change of a blog.
+// A soft deleted blog is deleted for the watchers
+// and an undeleted one is created again.
+type eventType int
+
+const (
+	eventCreated eventType = iota + 1
+	eventUpdated
+	eventDeleted
+)
+
+// blogEvent is a change of a blog sent to the watchers.
+type blogEvent struct {
+	Type eventType
+	// Item is the blog after the change, the removed blog for eventDeleted.
+	// Mongo change streams know only the ID of a removed blog,
+	// its author is known when the blog was changed earlier in the watch.
+	Item *blogItem
+	// Token resumes the watch right after this event.
+	Token string
+	Time  time.Time
+}
+
+// eventBus delivers the changes of the blogs to the watchers
+// within the process. It keeps the last eventBufferSize events,
+// so a watcher can resume from the token of the last event it got.
+// It is safe for concurrent use.
+type eventBus struct {
+	mu     sync.Mutex
+	epoch  string // tells the tokens of this process from the tokens of the previous ones
+	seq    uint64 // sequence number of the last event
+	events []*blogEvent
+	notify chan struct{} // closed and replaced on every event
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{
+		epoch:  strconv.FormatInt(time.Now().UnixNano(), 36),
+		notify: make(chan struct{}),
+	}
+}
+
+// Publish sends the change of the blog to the watchers.
+func (b *eventBus) Publish(typ eventType, item *blogItem) {
+	data := *item
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+
+	b.events = append(b.events, &blogEvent{
+		Type:  typ,
+		Item:  &data,
+		Token: b.token(b.seq),
+		Time:  time.Now().UTC(),
+	})
+
+	if len(b.events) > eventBufferSize {
+		b.events = b.events[len(b.events)-eventBufferSize:]
+	}
+
+	close(b.notify)
+	b.notify = make(chan struct{})
+}
+
+// Watch calls fn for every event after the resume token,
+// for the new events only when the token is empty.
+// It blocks until the context is done or fn returns an error.
+func (b *eventBus) Watch(ctx context.Context, resumeToken string, fn func(ev *blogEvent) error) error {
+	b.mu.Lock()
+	last := b.seq
+	b.mu.Unlock()
+
+	if resumeToken != "" {
+		var err error
+
+		last, err = b.parseToken(resumeToken)
+		if err != nil {
+			return err
+		}
+	}
+
+	for {
+		events, notify, err := b.after(last)
+		if err != nil {
+			return err
+		}
+
+		for _, ev := range events {
+			if err = fn(ev); err != nil {
+				return err
+			}
+		}
+
+		last += uint64(len(events))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-notify:
+		}
+	}
+}
+
+// after returns the buffered events after the sequence number
+// and the channel which is closed on the next event.
+func (b *eventBus) after(seq uint64) ([]*blogEvent, chan struct{}, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if seq > b.seq {
+		return nil, nil, errBadResumeToken
+	}
+
+	missed := b.seq - seq
+	if missed > uint64(len(b.events)) {
+		return nil, nil, errResumeTokenExpired
+	}
+
+	events := make([]*blogEvent, missed)
+	copy(events, b.events[len(b.events)-int(missed):])
+
+	return events, b.notify, nil
+}
+
+func (b *eventBus) token(seq uint64) string {
+	return b.epoch + "." + strconv.FormatUint(seq, 36)
+}
+
+func (b *eventBus) parseToken(token string) (uint64, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return 0, errBadResumeToken
+	}
+
+	if parts[0] != b.epoch {
+		return 0, fmt.Errorf("%w: the server was restarted", errResumeTokenExpired)
+	}
+
+	seq, err := strconv.ParseUint(parts[1], 36, 64)
+	if err != nil {
+		return 0, errBadResumeToken
+	}
+
+	return seq, nil
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sergeyzalunin/grpc-go-course/blog/blogpb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+)
+
+var errStopWatch = errors.New("stop watch")
+
+// watchTitles watches the bus from the token until n events are received.
+func watchTitles(t *testing.T, b *eventBus, token string, n int) ([]string, error) {
+	t.Helper()
+
+	var got []string
+
+	err := b.Watch(context.Background(), token, func(ev *blogEvent) error {
+		got = append(got, ev.Item.Title)
+		if len(got) == n {
+			return errStopWatch
+		}
+
+		return nil
+	})
+	if errors.Is(err, errStopWatch) {
+		err = nil
+	}
+
+	return got, err
+}
+
+func TestEventBusResume(t *testing.T) {
+	b := newEventBus()
+
+	var tokens []string
+
+	for _, title := range []string{"a", "b", "c"} {
+		b.Publish(eventCreated, &blogItem{Title: title})
+		tokens = append(tokens, b.token(b.seq))
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		n       int
+		want    []string
+		wantErr error
+	}{
+		{"after the first", tokens[0], 2, []string{"b", "c"}, nil},
+		{"after the last", tokens[1], 1, []string{"c"}, nil},
+		{"from the start of the process", b.token(0), 3, []string{"a", "b", "c"}, nil},
+		{"not a token", "nope", 0, nil, errBadResumeToken},
+		{"bad number", b.epoch + ".!", 0, nil, errBadResumeToken},
+		{"ahead of the bus", b.token(10), 0, nil, errBadResumeToken},
+		{"previous process", "0.1", 0, nil, errResumeTokenExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := watchTitles(t, b, tt.token, tt.n)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Watch() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Watch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventBusExpired(t *testing.T) {
+	b := newEventBus()
+	b.Publish(eventCreated, &blogItem{Title: "first"})
+	token := b.token(b.seq)
+
+	for i := 0; i < eventBufferSize+1; i++ {
+		b.Publish(eventUpdated, &blogItem{Title: "next"})
+	}
+
+	if _, err := watchTitles(t, b, token, 1); !errors.Is(err, errResumeTokenExpired) {
+		t.Errorf("Watch() error = %v, want errResumeTokenExpired", err)
+	}
+}
+
+func TestEventBusNewEvents(t *testing.T) {
+	b := newEventBus()
+	b.Publish(eventCreated, &blogItem{Title: "old"})
+
+	started := make(chan struct{})
+	done := make(chan []string)
+
+	go func() {
+		var got []string
+
+		close(started)
+
+		_ = b.Watch(context.Background(), "", func(ev *blogEvent) error {
+			got = append(got, ev.Item.Title)
+
+			return errStopWatch
+		})
+
+		done <- got
+	}()
+
+	<-started
+
+	// the watch may start after an event, it then waits for the next one
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		b.Publish(eventCreated, &blogItem{Title: "new"})
+
+		select {
+		case got := <-done:
+			if !reflect.DeepEqual(got, []string{"new"}) {
+				t.Errorf("Watch() = %v, want only the new events", got)
+			}
+
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchStore sends its events to the watchers.
+type watchStore struct {
+	BlogStore
+	events []*blogEvent
+}
+
+func (s *watchStore) Watch(ctx context.Context, resumeToken string, fn func(ev *blogEvent) error) error {
+	for _, ev := range s.events {
+		if err := fn(ev); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type watchStream struct {
+	grpc.ServerStream
+	sent []*blogpb.BlogEvent
+
+	// the watch is stopped after the event of the blog when set
+	ctx    context.Context
+	cancel func()
+	last   primitive.ObjectID
+}
+
+func (s *watchStream) Context() context.Context {
+	if s.ctx != nil {
+		return s.ctx
+	}
+
+	return context.Background()
+}
+
+func (s *watchStream) Send(ev *blogpb.BlogEvent) error {
+	s.sent = append(s.sent, ev)
+
+	if s.cancel != nil && ev.GetBlog().GetId() == s.last.Hex() {
+		s.cancel()
+	}
+
+	return nil
+}
+
+func TestWatchBlogsAuthor(t *testing.T) {
+	ids := []primitive.ObjectID{{11: 1}, {11: 2}, {11: 3}}
+	store := &watchStore{events: []*blogEvent{
+		{Type: eventCreated, Item: &blogItem{ID: ids[0], AuthorID: "alice"}},
+		{Type: eventCreated, Item: &blogItem{ID: ids[1], AuthorID: "bob"}},
+		{Type: eventDeleted, Item: &blogItem{ID: ids[1], AuthorID: "bob"}},
+		// a removed blog of a Mongo change stream
+		{Type: eventDeleted, Item: &blogItem{ID: ids[2]}},
+		{Type: eventDeleted, Item: &blogItem{ID: ids[0], AuthorID: "alice"}},
+	}}
+
+	tests := []struct {
+		author string
+		want   int
+	}{
+		{"", 5},
+		{"alice", 2},
+		{"bob", 2},
+	}
+
+	for _, tt := range tests {
+		stream := &watchStream{}
+
+		err := (&server{store: store}).WatchBlogs(&blogpb.WatchBlogsRequest{AuthorId: tt.author}, stream)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(stream.sent) != tt.want {
+			t.Errorf("author %q: sent %d events, want %d", tt.author, len(stream.sent), tt.want)
+		}
+
+		for _, ev := range stream.sent {
+			if tt.author != "" && ev.GetBlog().GetAuthorId() != tt.author {
+				t.Errorf("author %q: sent an event of %q", tt.author, ev.GetBlog().GetAuthorId())
+			}
+		}
+	}
+}
+
+// The purge of a blog in the trash is not sent again,
+// the watchers were told of its soft delete.
+func TestWatchBlogsPurge(t *testing.T) {
+	memory := newMemoryStore()
+	sql := newTestSQLStore(t)
+
+	stores := []struct {
+		name  string
+		store Store
+		bus   *eventBus
+	}{
+		{"memory", memory, memory.events},
+		{"sql", sql, sql.events},
+	}
+
+	for _, tt := range stores {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			blog, err := tt.store.Create(ctx, &blogItem{AuthorID: "alice", Title: "t"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err = tt.store.SoftDelete(ctx, blog.ID, 0); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err = tt.store.Delete(ctx, blog.ID, 0); err != nil {
+				t.Fatal(err)
+			}
+
+			// the watch ends at the event of the last blog
+			last, err := tt.store.Create(ctx, &blogItem{AuthorID: "alice", Title: "last"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			stream := &watchStream{ctx: ctx, cancel: cancel, last: last.ID}
+			req := &blogpb.WatchBlogsRequest{ResumeToken: tt.bus.token(0)}
+
+			_ = (&server{store: tt.store}).WatchBlogs(req, stream)
+
+			var got []blogpb.BlogEvent_Type
+			for _, ev := range stream.sent {
+				got = append(got, ev.GetType())
+			}
+
+			want := []blogpb.BlogEvent_Type{
+				blogpb.BlogEvent_CREATED,
+				blogpb.BlogEvent_DELETED,
+				blogpb.BlogEvent_CREATED,
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("WatchBlogs() sent %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import "fmt"
+
+// maxUpdateRetries limits the attempts of a partial update
+// when the blog keeps changing between the read and the write.
+const maxUpdateRetries = 3
+
+// updatableFields are the paths allowed in the update_mask of UpdateBlog.
+var updatableFields = map[string]func(dst, src *blogItem){
+	"author_id": func(dst, src *blogItem) { dst.AuthorID = src.AuthorID },
+	"title":     func(dst, src *blogItem) { dst.Title = src.Title },
+	"content":   func(dst, src *blogItem) { dst.Content = src.Content },
+	"published": func(dst, src *blogItem) { dst.Published = src.Published },
+	"tags":      func(dst, src *blogItem) { dst.Tags = src.Tags },
+	"category":  func(dst, src *blogItem) { dst.Category = src.Category },
+}
+
+// checkUpdateMask returns InvalidArgument when the mask has a path
+// which can't be updated.
+func checkUpdateMask(paths []string) error {
+	for _, path := range paths {
+		if _, ok := updatableFields[path]; !ok {
+			return badRequest("update_mask", fmt.Sprintf("has a field which can't be updated: %q", path))
+		}
+	}
+
+	return nil
+}
+
+// applyUpdateMask returns a copy of dst with the masked fields taken from src.
+func applyUpdateMask(dst, src *blogItem, paths []string) *blogItem {
+	data := *dst
+
+	for _, path := range paths {
+		updatableFields[path](&data, src)
+	}
+
+	return &data
+}
+
+// masksField reports whether an update of the paths changes the field,
+// an update without the paths changes all of them.
+func masksField(paths []string, field string) bool {
+	if len(paths) == 0 {
+		return true
+	}
+
+	for _, path := range paths {
+		if path == field {
+			return true
+		}
+	}
+
+	return false
+}
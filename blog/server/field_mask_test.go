@@ -0,0 +1,131 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCheckUpdateMask(t *testing.T) {
+	tests := []struct {
+		name    string
+		paths   []string
+		wantErr bool
+	}{
+		{"empty", nil, false},
+		{"updatable", []string{"title", "content", "tags"}, false},
+		{"id", []string{"id"}, true},
+		{"version", []string{"title", "version"}, true},
+		{"unknown", []string{"nope"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkUpdateMask(tt.paths)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkUpdateMask() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			if err == nil {
+				return
+			}
+
+			st := status.Convert(err)
+			if st.Code() != codes.InvalidArgument {
+				t.Errorf("code = %v, want InvalidArgument", st.Code())
+			}
+
+			if len(st.Details()) != 1 {
+				t.Fatalf("details = %v, want a BadRequest", st.Details())
+			}
+
+			br, ok := st.Details()[0].(*errdetails.BadRequest)
+			if !ok || br.GetFieldViolations()[0].GetField() != "update_mask" {
+				t.Errorf("details = %v, want a violation of update_mask", st.Details())
+			}
+		})
+	}
+}
+
+func TestApplyUpdateMask(t *testing.T) {
+	dst := &blogItem{
+		AuthorID: "alice",
+		Title:    "old title",
+		Content:  "old content",
+		Tags:     []string{"go"},
+		Version:  3,
+	}
+	src := &blogItem{
+		AuthorID:  "bob",
+		Title:     "new title",
+		Published: true,
+		Category:  "news",
+		Version:   7,
+	}
+
+	tests := []struct {
+		name  string
+		paths []string
+		want  blogItem
+	}{
+		{"no paths", nil, *dst},
+		{
+			"title",
+			[]string{"title"},
+			blogItem{AuthorID: "alice", Title: "new title", Content: "old content", Tags: []string{"go"}, Version: 3},
+		},
+		{
+			// a masked field which is empty in src is cleared
+			"content and tags cleared",
+			[]string{"content", "tags"},
+			blogItem{AuthorID: "alice", Title: "old title", Version: 3},
+		},
+		{
+			"published and category",
+			[]string{"published", "category"},
+			blogItem{
+				AuthorID:  "alice",
+				Title:     "old title",
+				Content:   "old content",
+				Tags:      []string{"go"},
+				Published: true,
+				Category:  "news",
+				Version:   3,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := applyUpdateMask(dst, src, tt.paths)
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("applyUpdateMask() = %+v, want %+v", *got, tt.want)
+			}
+
+			if got == dst || dst.Title != "old title" {
+				t.Error("applyUpdateMask() changed dst")
+			}
+		})
+	}
+}
+
+func TestMasksField(t *testing.T) {
+	tests := []struct {
+		paths []string
+		field string
+		want  bool
+	}{
+		{nil, "content", true},
+		{[]string{"title"}, "content", false},
+		{[]string{"title", "content"}, "content", true},
+	}
+
+	for _, tt := range tests {
+		if got := masksField(tt.paths, tt.field); got != tt.want {
+			t.Errorf("masksField(%v, %q) = %v, want %v", tt.paths, tt.field, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,334 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/sergeyzalunin/grpc-go-course/blog/blogpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// defaultPageSize is used by ListBlogsPage when page_size is not set.
+	defaultPageSize = 50
+	// maxPageSize limits the page_size of a request.
+	maxPageSize = 1000
+)
+
+var errBadPageToken = errors.New("invalid page token")
+
+// timeKeyLayout formats the times of the sort keys,
+// the times are in UTC, so the keys are sorted as the times.
+const timeKeyLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// listOrder is a field which the blogs are sorted by.
+// The blog ID is always used as the last sort key,
+// so the order is stable between the pages.
+type listOrder int
+
+const (
+	orderByID listOrder = iota
+	orderByTitle
+	orderByAuthor
+	orderByCreateTime
+	orderByUpdateTime
+)
+
+// field returns the stored name of the sort field.
+func (o listOrder) field() string {
+	switch o {
+	case orderByTitle:
+		return "title"
+	case orderByAuthor:
+		return "author_id"
+	case orderByCreateTime:
+		return "create_time"
+	case orderByUpdateTime:
+		return "update_time"
+	default:
+		return "_id"
+	}
+}
+
+// key returns the value of the sort field of the item as a string,
+// the keys are sorted in the same order as the values.
+func (o listOrder) key(item *blogItem) string {
+	switch o {
+	case orderByTitle:
+		return item.Title
+	case orderByAuthor:
+		return item.AuthorID
+	case orderByCreateTime:
+		return item.CreateTime.UTC().Format(timeKeyLayout)
+	case orderByUpdateTime:
+		return item.UpdateTime.UTC().Format(timeKeyLayout)
+	default:
+		return item.ID.Hex()
+	}
+}
+
+// value converts the key back to the value of the sort field.
+func (o listOrder) value(key string) (interface{}, error) {
+	switch o {
+	case orderByCreateTime, orderByUpdateTime:
+		t, err := time.Parse(timeKeyLayout, key)
+		if err != nil {
+			return nil, errBadPageToken
+		}
+
+		return t, nil
+	default:
+		return key, nil
+	}
+}
+
+// pageCursor points to the last blog of the previous page.
+type pageCursor struct {
+	Key string `json:"k"`
+	ID  string `json:"i"`
+}
+
+// listFilter selects the blogs by their fields,
+// the zero value of a field means no filtering by it,
+// except Deleted which selects either the deleted blogs or the other ones.
+type listFilter struct {
+	AuthorID      string    `json:"author_id,omitempty"`
+	CreatedAfter  time.Time `json:"created_after,omitempty"`
+	CreatedBefore time.Time `json:"created_before,omitempty"`
+	UpdatedAfter  time.Time `json:"updated_after,omitempty"`
+	UpdatedBefore time.Time `json:"updated_before,omitempty"`
+	Published     *bool     `json:"published,omitempty"`
+	Deleted       bool      `json:"deleted,omitempty"`
+	DeletedBefore time.Time `json:"deleted_before,omitempty"`
+	Tags          []string  `json:"tags,omitempty"`
+	AllTags       bool      `json:"all_tags,omitempty"` // match all the Tags instead of any
+	Category      string    `json:"category,omitempty"`
+}
+
+// match reports whether the item is selected by the filter.
+func (f *listFilter) match(item *blogItem) bool {
+	switch {
+	case f.AuthorID != "" && item.AuthorID != f.AuthorID,
+		!f.CreatedAfter.IsZero() && !item.CreateTime.After(f.CreatedAfter),
+		!f.CreatedBefore.IsZero() && !item.CreateTime.Before(f.CreatedBefore),
+		!f.UpdatedAfter.IsZero() && !item.UpdateTime.After(f.UpdatedAfter),
+		!f.UpdatedBefore.IsZero() && !item.UpdateTime.Before(f.UpdatedBefore),
+		f.Published != nil && item.Published != *f.Published,
+		item.deleted() != f.Deleted,
+		!f.DeletedBefore.IsZero() && !item.DeleteTime.Before(f.DeletedBefore),
+		len(f.Tags) > 0 && !hasTags(item, f.Tags, f.AllTags),
+		f.Category != "" && item.Category != f.Category:
+		return false
+	default:
+		return true
+	}
+}
+
+// listQuery selects the blogs returned by BlogStore.List.
+type listQuery struct {
+	listFilter
+
+	OrderBy    listOrder
+	Descending bool
+	After      *pageCursor // skip the blogs up to and including this one
+	Limit      int         // 0 means no limit
+}
+
+// digest identifies the filter and the order of the query.
+func (q *listQuery) digest() string {
+	b, err := json.Marshal(struct {
+		Filter     listFilter
+		OrderBy    listOrder
+		Descending bool
+	}{q.listFilter, q.OrderBy, q.Descending})
+	if err != nil {
+		// can't happen, the query has only strings, times, numbers and bools
+		panic(err)
+	}
+
+	sum := sha256.Sum256(b)
+
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+// pageToken is the content of the opaque page_token.
+// It keeps the digest of the query,
+// so a token can't be used with a different filter or order.
+type pageToken struct {
+	Query string     `json:"q"`
+	After pageCursor `json:"c"`
+}
+
+// listQueryFromPb converts the request into a store query.
+// Limit is left for the caller to set.
+func listQueryFromPb(req *blogpb.ListBlogRequest) (listQuery, error) {
+	q := listQuery{
+		listFilter: listFilter{
+			AuthorID:      req.GetAuthorId(),
+			CreatedAfter:  timeFromPb(req.GetCreatedAfter()),
+			CreatedBefore: timeFromPb(req.GetCreatedBefore()),
+			UpdatedAfter:  timeFromPb(req.GetUpdatedAfter()),
+			UpdatedBefore: timeFromPb(req.GetUpdatedBefore()),
+			Deleted:       req.GetDeleted(),
+			Tags:          normalizeTags(req.GetTags()),
+			Category:      req.GetCategory(),
+		},
+		Descending: req.GetDescending(),
+	}
+
+	switch req.GetOrderBy() {
+	case blogpb.ListBlogRequest_ID:
+		q.OrderBy = orderByID
+	case blogpb.ListBlogRequest_TITLE:
+		q.OrderBy = orderByTitle
+	case blogpb.ListBlogRequest_AUTHOR_ID:
+		q.OrderBy = orderByAuthor
+	case blogpb.ListBlogRequest_CREATE_TIME:
+		q.OrderBy = orderByCreateTime
+	case blogpb.ListBlogRequest_UPDATE_TIME:
+		q.OrderBy = orderByUpdateTime
+	default:
+		return q, &fieldError{"order_by", errors.New("has an unknown value")}
+	}
+
+	switch req.GetPublished() {
+	case blogpb.ListBlogRequest_ANY_STATE:
+	case blogpb.ListBlogRequest_PUBLISHED:
+		published := true
+		q.Published = &published
+	case blogpb.ListBlogRequest_DRAFT:
+		published := false
+		q.Published = &published
+	default:
+		return q, &fieldError{"published", errors.New("has an unknown value")}
+	}
+
+	switch req.GetTagMatch() {
+	case blogpb.ListBlogRequest_ANY_TAG:
+	case blogpb.ListBlogRequest_ALL_TAGS:
+		q.AllTags = true
+	default:
+		return q, &fieldError{"tag_match", errors.New("has an unknown value")}
+	}
+
+	if req.GetPageToken() == "" {
+		return q, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(req.GetPageToken())
+	if err != nil {
+		return q, errBadPageToken
+	}
+
+	var token pageToken
+	if err = json.Unmarshal(b, &token); err != nil {
+		return q, errBadPageToken
+	}
+
+	if token.Query != q.digest() {
+		return q, &fieldError{"page_token", errors.New("doesn't match the request")}
+	}
+
+	q.After = &token.After
+
+	return q, nil
+}
+
+// nextPageToken returns the token of the page which starts after the item.
+func nextPageToken(q listQuery, last *blogItem) string {
+	b, err := json.Marshal(pageToken{
+		Query: q.digest(),
+		After: pageCursor{
+			Key: q.OrderBy.key(last),
+			ID:  last.ID.Hex(),
+		},
+	})
+	if err != nil {
+		// can't happen, the token has only strings
+		panic(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// timeFromPb converts the timestamp to time, nil is the zero time.
+func timeFromPb(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+
+	return ts.AsTime()
+}
+
+// timeToPb converts the time to timestamp, the zero time is nil.
+func timeToPb(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
+
+// less reports whether a goes before b in the query order.
+func (q listQuery) less(a, b *blogItem) bool {
+	ka, kb := q.OrderBy.key(a), q.OrderBy.key(b)
+	if ka == kb {
+		ka, kb = a.ID.Hex(), b.ID.Hex()
+	}
+
+	if q.Descending {
+		return ka > kb
+	}
+
+	return ka < kb
+}
+
+// afterCursor reports whether the item goes after the query cursor.
+func (q listQuery) afterCursor(item *blogItem) bool {
+	if q.After == nil {
+		return true
+	}
+
+	key, cursor := q.OrderBy.key(item), q.After.Key
+	if key == cursor {
+		key, cursor = item.ID.Hex(), q.After.ID
+	}
+
+	if q.Descending {
+		return key < cursor
+	}
+
+	return key > cursor
+}
+
+// apply filters, sorts and limits the items in place
+// and returns the result. Used by the stores which can't query.
+func (q listQuery) apply(items []*blogItem) []*blogItem {
+	res := items[:0]
+
+	for _, item := range items {
+		if !q.match(item) {
+			continue
+		}
+
+		if !q.afterCursor(item) {
+			continue
+		}
+
+		res = append(res, item)
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return q.less(res[i], res[j])
+	})
+
+	if q.Limit > 0 && len(res) > q.Limit {
+		res = res[:q.Limit]
+	}
+
+	return res
+}
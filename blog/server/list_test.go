@@ -0,0 +1,179 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sergeyzalunin/grpc-go-course/blog/blogpb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// testBlogs returns blogs with the IDs in the creation order:
+// b0 by alice "c" at t0, b1 by bob "a" at t0+1s, b2 by alice "b" at t0+2s, b3 deleted.
+func testBlogs() []*blogItem {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	items := []*blogItem{
+		{AuthorID: "alice", Title: "c", Tags: []string{"go"}, Published: true},
+		{AuthorID: "bob", Title: "a", Tags: []string{"go", "grpc"}},
+		{AuthorID: "alice", Title: "b", Tags: []string{"grpc"}, Category: "news"},
+		{AuthorID: "bob", Title: "d", DeleteTime: t0},
+	}
+
+	for i, item := range items {
+		item.ID = primitive.ObjectID{11: byte(i + 1)}
+		item.CreateTime = t0.Add(time.Duration(i) * time.Second)
+		item.UpdateTime = item.CreateTime
+	}
+
+	return items
+}
+
+func titles(items []*blogItem) []string {
+	res := []string{}
+	for _, item := range items {
+		res = append(res, item.Title)
+	}
+
+	return res
+}
+
+func TestListQueryApply(t *testing.T) {
+	blogs := testBlogs()
+	published := true
+
+	tests := []struct {
+		name string
+		q    listQuery
+		want []string
+	}{
+		{"all by ID", listQuery{}, []string{"c", "a", "b"}},
+		{"descending", listQuery{Descending: true}, []string{"b", "a", "c"}},
+		{"by title", listQuery{OrderBy: orderByTitle}, []string{"a", "b", "c"}},
+		{"by author, then ID", listQuery{OrderBy: orderByAuthor}, []string{"c", "b", "a"}},
+		{"author", listQuery{listFilter: listFilter{AuthorID: "alice"}}, []string{"c", "b"}},
+		{"published", listQuery{listFilter: listFilter{Published: &published}}, []string{"c"}},
+		{"deleted", listQuery{listFilter: listFilter{Deleted: true}}, []string{"d"}},
+		{"any tag", listQuery{listFilter: listFilter{Tags: []string{"go", "grpc"}}}, []string{"c", "a", "b"}},
+		{"all tags", listQuery{listFilter: listFilter{Tags: []string{"go", "grpc"}, AllTags: true}}, []string{"a"}},
+		{"category", listQuery{listFilter: listFilter{Category: "news"}}, []string{"b"}},
+		{"created after", listQuery{listFilter: listFilter{CreatedAfter: blogs[0].CreateTime}}, []string{"a", "b"}},
+		{"limit", listQuery{OrderBy: orderByTitle, Limit: 2}, []string{"a", "b"}},
+		{
+			"after cursor",
+			listQuery{OrderBy: orderByTitle, After: &pageCursor{Key: "a", ID: blogs[1].ID.Hex()}},
+			[]string{"b", "c"},
+		},
+		{
+			"after cursor descending",
+			listQuery{OrderBy: orderByTitle, Descending: true, After: &pageCursor{Key: "b", ID: blogs[2].ID.Hex()}},
+			[]string{"a"},
+		},
+		{
+			// the blogs with the same key are ordered by ID after the cursor
+			"after cursor of equal keys",
+			listQuery{
+				listFilter: listFilter{AuthorID: "alice"},
+				OrderBy:    orderByAuthor,
+				After:      &pageCursor{Key: "alice", ID: blogs[0].ID.Hex()},
+			},
+			[]string{"b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := titles(tt.q.apply(testBlogs()))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("apply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// The pages of the tokens list every blog once.
+func TestListPages(t *testing.T) {
+	orders := []blogpb.ListBlogRequest_OrderBy{
+		blogpb.ListBlogRequest_ID,
+		blogpb.ListBlogRequest_TITLE,
+		blogpb.ListBlogRequest_AUTHOR_ID,
+		blogpb.ListBlogRequest_CREATE_TIME,
+		blogpb.ListBlogRequest_UPDATE_TIME,
+	}
+
+	for _, orderBy := range orders {
+		for _, descending := range []bool{false, true} {
+			req := &blogpb.ListBlogRequest{OrderBy: orderBy, Descending: descending}
+
+			q, err := listQueryFromPb(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := titles(q.apply(testBlogs()))
+
+			var got []string
+
+			for {
+				q, err = listQueryFromPb(req)
+				if err != nil {
+					t.Fatalf("%v descending %v: %v", orderBy, descending, err)
+				}
+
+				q.Limit = 1
+
+				page := q.apply(testBlogs())
+				if len(page) == 0 {
+					break
+				}
+
+				got = append(got, titles(page)...)
+				req.PageToken = nextPageToken(q, page[0])
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%v descending %v: pages = %v, want %v", orderBy, descending, got, want)
+			}
+		}
+	}
+}
+
+func TestListQueryFromPbToken(t *testing.T) {
+	q, err := listQueryFromPb(&blogpb.ListBlogRequest{AuthorId: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token := nextPageToken(q, testBlogs()[0])
+
+	tests := []struct {
+		name    string
+		req     *blogpb.ListBlogRequest
+		wantErr bool
+	}{
+		{"same query", &blogpb.ListBlogRequest{AuthorId: "alice", PageToken: token}, false},
+		{"other filter", &blogpb.ListBlogRequest{AuthorId: "bob", PageToken: token}, true},
+		{"other order", &blogpb.ListBlogRequest{AuthorId: "alice", Descending: true, PageToken: token}, true},
+		{"not base64", &blogpb.ListBlogRequest{AuthorId: "alice", PageToken: "!"}, true},
+		{"not JSON", &blogpb.ListBlogRequest{AuthorId: "alice", PageToken: "e30x"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := listQueryFromPb(tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("listQueryFromPb() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			if err == nil && (q.After == nil || q.After.ID != testBlogs()[0].ID.Hex()) {
+				t.Errorf("After = %v, want the cursor of the first blog", q.After)
+			}
+
+			var fe *fieldError
+			if err != nil && !errors.Is(err, errBadPageToken) && !errors.As(err, &fe) {
+				t.Errorf("error = %v, want errBadPageToken or a field error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,417 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryStore keeps blogs and comments in memory.
+// It is safe for concurrent use and is handy
+// to run the server without a database.
+type memoryStore struct {
+	mu    sync.RWMutex
+	items map[primitive.ObjectID]*blogItem
+	order []primitive.ObjectID // keeps the insertion order for List
+	index *invertedIndex
+
+	revisions map[primitive.ObjectID][]*blogRevision
+	events    *eventBus
+	comments  map[primitive.ObjectID]*commentItem
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		items: make(map[primitive.ObjectID]*blogItem),
+		index: newInvertedIndex(),
+
+		revisions: make(map[primitive.ObjectID][]*blogRevision),
+		events:    newEventBus(),
+		comments:  make(map[primitive.ObjectID]*commentItem),
+	}
+}
+
+// Ping never fails, the memory is always there.
+func (m *memoryStore) Ping(context.Context) error {
+	return nil
+}
+
+func (m *memoryStore) Create(_ context.Context, item *blogItem) (*blogItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data := newBlogData(item)
+	if _, ok := m.items[data.ID]; ok {
+		return nil, errBlogExists
+	}
+
+	m.items[data.ID] = &data
+	m.order = append(m.order, data.ID)
+	m.index.Add(&data)
+	m.revisions[data.ID] = []*blogRevision{newRevision(&data)}
+	m.events.Publish(eventCreated, &data)
+
+	res := data
+
+	return &res, nil
+}
+
+func (m *memoryStore) Read(_ context.Context, id primitive.ObjectID) (*blogItem, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	data, ok := m.items[id]
+	if !ok || data.deleted() {
+		return nil, errBlogNotFound
+	}
+
+	res := *data
+
+	return &res, nil
+}
+
+func (m *memoryStore) Author(_ context.Context, id primitive.ObjectID) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	data, ok := m.items[id]
+	if !ok {
+		return "", errBlogNotFound
+	}
+
+	return data.AuthorID, nil
+}
+
+func (m *memoryStore) Update(_ context.Context, item *blogItem, version int64) (*blogItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	prev, ok := m.items[item.ID]
+	if !ok || prev.deleted() {
+		return nil, errBlogNotFound
+	}
+
+	if version != 0 && prev.Version != version {
+		return nil, errVersionMismatch
+	}
+
+	data := *item
+	data.Revision = prev.Revision + 1
+	data.Version = prev.Version + 1
+	data.CreateTime = prev.CreateTime
+	data.UpdateTime = storeNow()
+
+	m.items[data.ID] = &data
+	m.index.Add(&data)
+	m.revisions[data.ID] = append(m.revisions[data.ID], newRevision(&data))
+	m.events.Publish(eventUpdated, &data)
+
+	res := data
+
+	return &res, nil
+}
+
+func (m *memoryStore) Delete(_ context.Context, id primitive.ObjectID, version int64) (*blogItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, ok := m.items[id]
+	if !ok {
+		return nil, errBlogNotFound
+	}
+
+	if version != 0 && data.Version != version {
+		return nil, errVersionMismatch
+	}
+
+	delete(m.items, id)
+	delete(m.revisions, id)
+	m.index.Remove(id)
+
+	for cid, comment := range m.comments {
+		if comment.BlogID == id {
+			delete(m.comments, cid)
+		}
+	}
+
+	// the watchers were told of the soft delete of the blog in the trash
+	if !data.deleted() {
+		m.events.Publish(eventDeleted, data)
+	}
+
+	for i := range m.order {
+		if m.order[i] == id {
+			m.order = append(m.order[:i], m.order[i+1:]...)
+
+			break
+		}
+	}
+
+	return data, nil
+}
+
+func (m *memoryStore) SoftDelete(_ context.Context, id primitive.ObjectID, version int64) (*blogItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	prev, ok := m.items[id]
+	if !ok || prev.deleted() {
+		return nil, errBlogNotFound
+	}
+
+	if version != 0 && prev.Version != version {
+		return nil, errVersionMismatch
+	}
+
+	data := *prev
+	data.Version++
+	data.DeleteTime = storeNow()
+
+	m.items[id] = &data
+	m.index.Remove(id)
+	m.events.Publish(eventDeleted, &data)
+
+	res := data
+
+	return &res, nil
+}
+
+func (m *memoryStore) Undelete(_ context.Context, id primitive.ObjectID) (*blogItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	prev, ok := m.items[id]
+	if !ok {
+		return nil, errBlogNotFound
+	}
+
+	data := *prev
+
+	if prev.deleted() {
+		data.Version++
+		data.DeleteTime = time.Time{}
+
+		m.items[id] = &data
+		m.index.Add(&data)
+		m.events.Publish(eventCreated, &data)
+	}
+
+	res := data
+
+	return &res, nil
+}
+
+func (m *memoryStore) List(ctx context.Context, q listQuery, fn func(item *blogItem) error) error {
+	// take a snapshot, so fn is called without holding the lock
+	m.mu.RLock()
+	items := make([]*blogItem, 0, len(m.order))
+
+	for _, id := range m.order {
+		data := *m.items[id]
+		items = append(items, &data)
+	}
+	m.mu.RUnlock()
+
+	for _, item := range q.apply(items) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *memoryStore) ListTags(_ context.Context, f listFilter) ([]tagCount, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	items := make([]*blogItem, 0, len(m.items))
+
+	for _, item := range m.items {
+		if f.match(item) {
+			items = append(items, item)
+		}
+	}
+
+	return countTags(items), nil
+}
+
+func (m *memoryStore) Search(_ context.Context, query string, limit int) ([]searchHit, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	hits := []searchHit{}
+
+	for _, hit := range m.index.Search(query, limit) {
+		data := *m.items[hit.ID]
+		hits = append(hits, searchHit{Item: &data, Score: hit.Score})
+	}
+
+	return hits, nil
+}
+
+func (m *memoryStore) ListRevisions(_ context.Context, id primitive.ObjectID) ([]*blogRevision, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if data, ok := m.items[id]; !ok || data.deleted() {
+		return nil, errBlogNotFound
+	}
+
+	revisions := make([]*blogRevision, 0, len(m.revisions[id]))
+
+	for _, rev := range m.revisions[id] {
+		res := *rev
+		revisions = append(revisions, &res)
+	}
+
+	return revisions, nil
+}
+
+func (m *memoryStore) ReadRevision(
+	_ context.Context,
+	id primitive.ObjectID,
+	number int64,
+) (*blogRevision, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if data, ok := m.items[id]; !ok || data.deleted() {
+		return nil, errBlogNotFound
+	}
+
+	// revisions are numbered from 1 without gaps
+	revisions := m.revisions[id]
+	if number < 1 || number > int64(len(revisions)) {
+		return nil, errRevisionNotFound
+	}
+
+	res := *revisions[number-1]
+
+	return &res, nil
+}
+
+func (m *memoryStore) Watch(ctx context.Context, resumeToken string, fn func(ev *blogEvent) error) error {
+	return m.events.Watch(ctx, resumeToken, fn)
+}
+
+func (m *memoryStore) CreateComment(_ context.Context, item *commentItem) (*commentItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data := newCommentData(item)
+	m.comments[data.ID] = &data
+
+	res := data
+
+	return &res, nil
+}
+
+func (m *memoryStore) ReadComment(_ context.Context, id primitive.ObjectID) (*commentItem, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	data, ok := m.comments[id]
+	if !ok {
+		return nil, errCommentNotFound
+	}
+
+	res := *data
+
+	return &res, nil
+}
+
+func (m *memoryStore) UpdateComment(_ context.Context, item *commentItem) (*commentItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	prev, ok := m.comments[item.ID]
+	if !ok {
+		return nil, errCommentNotFound
+	}
+
+	data := *prev
+	data.Content = item.Content
+	data.State = item.State
+	data.UpdateTime = storeNow()
+
+	m.comments[data.ID] = &data
+
+	res := data
+
+	return &res, nil
+}
+
+func (m *memoryStore) DeleteComment(_ context.Context, id primitive.ObjectID) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.comments[id]; !ok {
+		return 0, errCommentNotFound
+	}
+
+	// delete the replies level by level
+	deleted := map[primitive.ObjectID]bool{id: true}
+
+	for found := true; found; {
+		found = false
+
+		for cid, comment := range m.comments {
+			if !deleted[cid] && deleted[comment.ParentID] {
+				deleted[cid] = true
+				found = true
+			}
+		}
+	}
+
+	for cid := range deleted {
+		delete(m.comments, cid)
+	}
+
+	return len(deleted), nil
+}
+
+func (m *memoryStore) ListComments(
+	ctx context.Context,
+	q commentQuery,
+	fn func(item *commentItem) error,
+) error {
+	// take a snapshot, so fn is called without holding the lock
+	m.mu.RLock()
+	items := make([]*commentItem, 0)
+
+	for _, comment := range m.comments {
+		if q.match(comment) {
+			data := *comment
+			items = append(items, &data)
+		}
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ID.Hex() < items[j].ID.Hex()
+	})
+
+	if q.Limit > 0 && len(items) > q.Limit {
+		items = items[:q.Limit]
+	}
+
+	for _, item := range items {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
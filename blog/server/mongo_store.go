@@ -0,0 +1,1022 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// mongoStore keeps blogs in a MongoDB collection
+// and their revisions and comments in other ones.
+// The changes are watched with the change streams when the server
+// supports them (a replica set), otherwise only the changes
+// made by this process are watched with an in-process event bus.
+// A blog and its revision are written in a transaction when the server
+// supports them, otherwise the blog write is undone when the revision one fails.
+type mongoStore struct {
+	client     *mongo.Client
+	collection *mongo.Collection
+	revisions  *mongo.Collection
+	comments   *mongo.Collection
+
+	changeStreams bool
+	transactions  bool
+	events        *eventBus
+}
+
+// newMongoStore connects to the MongoDB by uri as the user,
+// without authentication when it is empty,
+// and checks that the server is reachable.
+func newMongoStore(uri, username, password string) (*mongoStore, error) {
+	opts := options.Client().ApplyURI(uri)
+	if username != "" {
+		opts.SetAuth(options.Credential{Username: username, Password: password})
+	}
+
+	client, err := mongo.NewClient(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err = client.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	err = client.Ping(ctx, readpref.Primary())
+	if err != nil {
+		return nil, err
+	}
+
+	m := &mongoStore{
+		client:     client,
+		collection: client.Database("testing").Collection("numbers"),
+		revisions:  client.Database("testing").Collection("revisions"),
+		comments:   client.Database("testing").Collection("comments"),
+		events:     newEventBus(),
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err = m.createIndexes(ctx); err != nil {
+		return nil, err
+	}
+
+	m.changeStreams = m.supportsChangeStreams(ctx)
+	if !m.changeStreams {
+		log.Println("MongoDB has no change streams, only the changes of this server are watched")
+	}
+
+	m.transactions = m.supportsTransactions(ctx)
+	if !m.transactions {
+		log.Println("MongoDB has no transactions, the blogs and their revisions are written one by one")
+	}
+
+	return m, nil
+}
+
+// createIndexes creates the indexes used by the queries,
+// the existing ones are left as they are.
+func (m *mongoStore) createIndexes(ctx context.Context) error {
+	_, err := m.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "title", Value: "text"},
+			{Key: "content", Value: "text"},
+		},
+		Options: options.Index().
+			SetName("blog_text").
+			SetWeights(bson.M{"title": titleWeight, "content": 1}),
+	})
+	if err != nil {
+		return fmt.Errorf("cannot create text index: %w", err)
+	}
+
+	_, err = m.revisions.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "blog_id", Value: 1},
+			{Key: "number", Value: 1},
+		},
+		Options: options.Index().
+			SetName("blog_revision").
+			SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("cannot create revisions index: %w", err)
+	}
+
+	_, err = m.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "tags", Value: 1}},
+		Options: options.Index().SetName("blog_tags"),
+	})
+	if err != nil {
+		return fmt.Errorf("cannot create tags index: %w", err)
+	}
+
+	_, err = m.comments.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "blog_id", Value: 1},
+				{Key: "_id", Value: 1},
+			},
+			Options: options.Index().SetName("comment_blog"),
+		},
+		{
+			Keys:    bson.D{{Key: "parent_id", Value: 1}},
+			Options: options.Index().SetName("comment_parent"),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("cannot create comments indexes: %w", err)
+	}
+
+	return nil
+}
+
+// Close disconnects from the MongoDB.
+func (m *mongoStore) Close(ctx context.Context) error {
+	return m.client.Disconnect(ctx)
+}
+
+func (m *mongoStore) Ping(ctx context.Context) error {
+	return m.client.Ping(ctx, readpref.Primary())
+}
+
+func (m *mongoStore) Create(ctx context.Context, item *blogItem) (*blogItem, error) {
+	data := newBlogData(item)
+
+	err := m.inTransaction(ctx, func(ctx context.Context) error {
+		if _, err := m.collection.InsertOne(ctx, data); err != nil {
+			return mongoError(err)
+		}
+
+		if _, err := m.revisions.InsertOne(ctx, newRevision(&data)); err != nil {
+			m.undo(ctx, func(ctx context.Context) error {
+				_, err := m.collection.DeleteOne(ctx, bson.M{"_id": data.ID, "version": data.Version})
+
+				return err
+			})
+
+			return fmt.Errorf("cannot save revision: %w", mongoError(err))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	m.publish(eventCreated, &data)
+
+	return &data, nil
+}
+
+func (m *mongoStore) Read(ctx context.Context, id primitive.ObjectID) (*blogItem, error) {
+	// create an empty document
+	data := &blogItem{}
+	filter := bson.M{"_id": id, "delete_time": nil}
+
+	err := m.collection.FindOne(ctx, filter).Decode(data)
+	if err != nil {
+		return nil, mongoError(err)
+	}
+
+	return data, nil
+}
+
+func (m *mongoStore) Author(ctx context.Context, id primitive.ObjectID) (string, error) {
+	data := &blogItem{}
+	opts := options.FindOne().SetProjection(bson.M{"author_id": 1})
+
+	err := m.collection.FindOne(ctx, bson.M{"_id": id}, opts).Decode(data)
+	if err != nil {
+		return "", mongoError(err)
+	}
+
+	return data.AuthorID, nil
+}
+
+func (m *mongoStore) Update(ctx context.Context, item *blogItem, version int64) (*blogItem, error) {
+	filter := bson.M{
+		"_id":         item.ID,
+		"delete_time": nil,
+	}
+
+	if version != 0 {
+		filter["version"] = version
+	}
+
+	now := storeNow()
+	update := bson.M{
+		"$set": bson.M{
+			"author_id":   item.AuthorID,
+			"title":       item.Title,
+			"content":     item.Content,
+			"published":   item.Published,
+			"tags":        item.Tags,
+			"category":    item.Category,
+			"update_time": now,
+		},
+		"$inc": bson.M{
+			"revision": 1,
+			"version":  1,
+		},
+	}
+
+	// the blog before the update, so it can be put back without a transaction
+	opts := &options.FindOneAndUpdateOptions{}
+	opts.SetReturnDocument(options.Before)
+
+	var data *blogItem
+
+	err := m.inTransaction(ctx, func(ctx context.Context) error {
+		old := &blogItem{}
+
+		err := m.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(old)
+		if err != nil {
+			return m.writeError(ctx, err, filter)
+		}
+
+		updated := *old
+		updated.AuthorID = item.AuthorID
+		updated.Title = item.Title
+		updated.Content = item.Content
+		updated.Published = item.Published
+		updated.Tags = item.Tags
+		updated.Category = item.Category
+		updated.UpdateTime = now
+		updated.Revision++
+		updated.Version++
+
+		if _, err = m.revisions.InsertOne(ctx, newRevision(&updated)); err != nil {
+			m.undo(ctx, func(ctx context.Context) error {
+				_, err := m.collection.ReplaceOne(ctx, bson.M{"_id": old.ID, "version": updated.Version}, old)
+
+				return err
+			})
+
+			return fmt.Errorf("cannot save revision: %w", mongoError(err))
+		}
+
+		data = &updated
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	m.publish(eventUpdated, data)
+
+	return data, nil
+}
+
+func (m *mongoStore) Delete(ctx context.Context, id primitive.ObjectID, version int64) (*blogItem, error) {
+	filter := bson.M{
+		"_id": id,
+	}
+
+	if version != 0 {
+		filter["version"] = version
+	}
+
+	data := &blogItem{}
+
+	err := m.inTransaction(ctx, func(ctx context.Context) error {
+		err := m.collection.FindOneAndDelete(ctx, filter).Decode(data)
+		if err != nil {
+			return m.writeError(ctx, err, filter)
+		}
+
+		if _, err = m.revisions.DeleteMany(ctx, bson.M{"blog_id": id}); err != nil {
+			return fmt.Errorf("cannot delete revisions: %w", mongoError(err))
+		}
+
+		if _, err = m.comments.DeleteMany(ctx, bson.M{"blog_id": id}); err != nil {
+			return fmt.Errorf("cannot delete comments: %w", mongoError(err))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the watchers were told of the soft delete of the blog in the trash
+	if !data.deleted() {
+		m.publish(eventDeleted, data)
+	}
+
+	return data, nil
+}
+
+func (m *mongoStore) SoftDelete(ctx context.Context, id primitive.ObjectID, version int64) (*blogItem, error) {
+	filter := bson.M{
+		"_id":         id,
+		"delete_time": nil,
+	}
+
+	if version != 0 {
+		filter["version"] = version
+	}
+
+	update := bson.M{
+		"$set": bson.M{"delete_time": storeNow()},
+		"$inc": bson.M{"version": 1},
+	}
+
+	opts := &options.FindOneAndUpdateOptions{}
+	opts.SetReturnDocument(options.After)
+
+	data := &blogItem{}
+
+	err := m.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(data)
+	if err != nil {
+		return nil, m.writeError(ctx, err, filter)
+	}
+
+	m.publish(eventDeleted, data)
+
+	return data, nil
+}
+
+func (m *mongoStore) Undelete(ctx context.Context, id primitive.ObjectID) (*blogItem, error) {
+	filter := bson.M{
+		"_id":         id,
+		"delete_time": bson.M{"$ne": nil},
+	}
+
+	update := bson.M{
+		"$unset": bson.M{"delete_time": ""},
+		"$inc":   bson.M{"version": 1},
+	}
+
+	opts := &options.FindOneAndUpdateOptions{}
+	opts.SetReturnDocument(options.After)
+
+	data := &blogItem{}
+
+	err := m.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(data)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		// missing or not deleted
+		return m.Read(ctx, id)
+	}
+
+	if err != nil {
+		return nil, mongoError(err)
+	}
+
+	m.publish(eventCreated, data)
+
+	return data, nil
+}
+
+func (m *mongoStore) List(ctx context.Context, q listQuery, fn func(item *blogItem) error) error {
+	filter, err := mongoListFilter(q)
+	if err != nil {
+		return err
+	}
+
+	dir := 1
+	if q.Descending {
+		dir = -1
+	}
+
+	sort := bson.D{{Key: q.OrderBy.field(), Value: dir}}
+	if q.OrderBy != orderByID {
+		sort = append(sort, bson.E{Key: "_id", Value: dir})
+	}
+
+	opts := options.Find().SetSort(sort)
+	if q.Limit > 0 {
+		opts.SetLimit(int64(q.Limit))
+	}
+
+	cur, err := m.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return mongoError(err)
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		data := &blogItem{}
+
+		err := cur.Decode(data)
+		if err != nil {
+			return fmt.Errorf("error while decoding data: %w", err)
+		}
+
+		if err = fn(data); err != nil {
+			return err
+		}
+	}
+
+	return cur.Err()
+}
+
+func (m *mongoStore) ListTags(ctx context.Context, f listFilter) ([]tagCount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: mongoFilter(f)}},
+		{{Key: "$unwind", Value: "$tags"}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$tags",
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: bson.D{
+			{Key: "count", Value: -1},
+			{Key: "_id", Value: 1},
+		}}},
+	}
+
+	cur, err := m.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, mongoError(err)
+	}
+
+	counts := []tagCount{}
+	if err = cur.All(ctx, &counts); err != nil {
+		return nil, fmt.Errorf("error while decoding data: %w", err)
+	}
+
+	return counts, nil
+}
+
+func (m *mongoStore) Search(ctx context.Context, query string, limit int) ([]searchHit, error) {
+	score := bson.M{"$meta": "textScore"}
+
+	opts := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.M{"score": score})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	filter := bson.M{
+		"$text":       bson.M{"$search": query},
+		"delete_time": nil,
+	}
+
+	cur, err := m.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, mongoError(err)
+	}
+	defer cur.Close(ctx)
+
+	hits := []searchHit{}
+
+	for cur.Next(ctx) {
+		data := &struct {
+			blogItem `bson:",inline"`
+			Score    float64 `bson:"score"`
+		}{}
+
+		if err := cur.Decode(data); err != nil {
+			return nil, fmt.Errorf("error while decoding data: %w", err)
+		}
+
+		hits = append(hits, searchHit{Item: &data.blogItem, Score: data.Score})
+	}
+
+	return hits, cur.Err()
+}
+
+func (m *mongoStore) ListRevisions(ctx context.Context, id primitive.ObjectID) ([]*blogRevision, error) {
+	if _, err := m.Read(ctx, id); err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(bson.M{"number": 1})
+
+	cur, err := m.revisions.Find(ctx, bson.M{"blog_id": id}, opts)
+	if err != nil {
+		return nil, mongoError(err)
+	}
+
+	revisions := []*blogRevision{}
+	if err = cur.All(ctx, &revisions); err != nil {
+		return nil, fmt.Errorf("error while decoding data: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (m *mongoStore) ReadRevision(
+	ctx context.Context,
+	id primitive.ObjectID,
+	number int64,
+) (*blogRevision, error) {
+	if _, err := m.Read(ctx, id); err != nil {
+		return nil, err
+	}
+
+	rev := &blogRevision{}
+
+	err := m.revisions.FindOne(ctx, bson.M{"blog_id": id, "number": number}).Decode(rev)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errRevisionNotFound
+		}
+
+		return nil, mongoError(err)
+	}
+
+	return rev, nil
+}
+
+func (m *mongoStore) CreateComment(ctx context.Context, item *commentItem) (*commentItem, error) {
+	data := newCommentData(item)
+
+	if _, err := m.comments.InsertOne(ctx, data); err != nil {
+		return nil, mongoError(err)
+	}
+
+	return &data, nil
+}
+
+func (m *mongoStore) ReadComment(ctx context.Context, id primitive.ObjectID) (*commentItem, error) {
+	data := &commentItem{}
+
+	err := m.comments.FindOne(ctx, bson.M{"_id": id}).Decode(data)
+	if err != nil {
+		return nil, commentError(err)
+	}
+
+	return data, nil
+}
+
+func (m *mongoStore) UpdateComment(ctx context.Context, item *commentItem) (*commentItem, error) {
+	update := bson.M{
+		"$set": bson.M{
+			"content":     item.Content,
+			"state":       item.State,
+			"update_time": storeNow(),
+		},
+	}
+
+	opts := &options.FindOneAndUpdateOptions{}
+	opts.SetReturnDocument(options.After)
+
+	data := &commentItem{}
+
+	err := m.comments.FindOneAndUpdate(ctx, bson.M{"_id": item.ID}, update, opts).Decode(data)
+	if err != nil {
+		return nil, commentError(err)
+	}
+
+	return data, nil
+}
+
+func (m *mongoStore) DeleteComment(ctx context.Context, id primitive.ObjectID) (int, error) {
+	if _, err := m.ReadComment(ctx, id); err != nil {
+		return 0, err
+	}
+
+	// collect the replies level by level
+	ids := []primitive.ObjectID{id}
+	parents := ids
+
+	for len(parents) > 0 {
+		opts := options.Find().SetProjection(bson.M{"_id": 1})
+
+		cur, err := m.comments.Find(ctx, bson.M{"parent_id": bson.M{"$in": parents}}, opts)
+		if err != nil {
+			return 0, mongoError(err)
+		}
+
+		var replies []struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+
+		if err = cur.All(ctx, &replies); err != nil {
+			return 0, fmt.Errorf("error while decoding data: %w", err)
+		}
+
+		parents = make([]primitive.ObjectID, 0, len(replies))
+		for _, reply := range replies {
+			parents = append(parents, reply.ID)
+		}
+
+		ids = append(ids, parents...)
+	}
+
+	res, err := m.comments.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return 0, mongoError(err)
+	}
+
+	return int(res.DeletedCount), nil
+}
+
+func (m *mongoStore) ListComments(
+	ctx context.Context,
+	q commentQuery,
+	fn func(item *commentItem) error,
+) error {
+	filter := bson.M{"blog_id": q.BlogID}
+
+	if len(q.States) > 0 {
+		filter["state"] = bson.M{"$in": q.States}
+	}
+
+	if q.After != nil {
+		filter["_id"] = bson.M{"$gt": *q.After}
+	}
+
+	opts := options.Find().SetSort(bson.M{"_id": 1})
+	if q.Limit > 0 {
+		opts.SetLimit(int64(q.Limit))
+	}
+
+	cur, err := m.comments.Find(ctx, filter, opts)
+	if err != nil {
+		return mongoError(err)
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		data := &commentItem{}
+
+		if err := cur.Decode(data); err != nil {
+			return fmt.Errorf("error while decoding data: %w", err)
+		}
+
+		if err = fn(data); err != nil {
+			return err
+		}
+	}
+
+	return mongoError(cur.Err())
+}
+
+// supportsChangeStreams reports whether the server can watch the collection,
+// a standalone server can't.
+func (m *mongoStore) supportsChangeStreams(ctx context.Context) bool {
+	cs, err := m.collection.Watch(ctx, mongo.Pipeline{})
+	if err != nil {
+		return false
+	}
+
+	cs.Close(ctx)
+
+	return true
+}
+
+// supportsTransactions reports whether the server runs transactions,
+// a replica set of MongoDB 4.0 or later does.
+func (m *mongoStore) supportsTransactions(ctx context.Context) bool {
+	var reply struct {
+		SetName        string `bson:"setName"`
+		MaxWireVersion int    `bson:"maxWireVersion"`
+	}
+
+	err := m.client.Database("admin").RunCommand(ctx, bson.D{{Key: "isMaster", Value: 1}}).Decode(&reply)
+
+	return err == nil && reply.SetName != "" && reply.MaxWireVersion >= 7
+}
+
+// inTransaction runs fn in a transaction when the server supports them,
+// otherwise it just runs fn.
+func (m *mongoStore) inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !m.transactions {
+		return fn(ctx)
+	}
+
+	session, err := m.client.StartSession()
+	if err != nil {
+		return mongoError(err)
+	}
+	defer session.EndSession(context.Background())
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+
+	return err
+}
+
+// undo reverts a write of a failed inTransaction without a transaction,
+// the aborted transaction reverts it otherwise.
+func (m *mongoStore) undo(ctx context.Context, revert func(ctx context.Context) error) {
+	if m.transactions {
+		return
+	}
+
+	// the context of the request may be done already
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+
+	if err := revert(ctx); err != nil {
+		log.Printf("Cannot undo the blog write: %v", err)
+	}
+}
+
+// publish sends the change to the in-process watchers
+// when there are no change streams.
+func (m *mongoStore) publish(typ eventType, item *blogItem) {
+	if !m.changeStreams {
+		m.events.Publish(typ, item)
+	}
+}
+
+// mongoChange is a change stream event of the blogs collection.
+type mongoChange struct {
+	OperationType string              `bson:"operationType"`
+	FullDocument  *blogItem           `bson:"fullDocument"`
+	ClusterTime   primitive.Timestamp `bson:"clusterTime"`
+	DocumentKey   struct {
+		ID primitive.ObjectID `bson:"_id"`
+	} `bson:"documentKey"`
+	UpdateDescription struct {
+		RemovedFields []string `bson:"removedFields"`
+	} `bson:"updateDescription"`
+}
+
+// undeleted reports whether the change clears the delete time of the blog.
+func (c *mongoChange) undeleted() bool {
+	for _, field := range c.UpdateDescription.RemovedFields {
+		if field == "delete_time" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *mongoStore) Watch(ctx context.Context, resumeToken string, fn func(ev *blogEvent) error) error {
+	if !m.changeStreams {
+		return m.events.Watch(ctx, resumeToken, fn)
+	}
+
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+
+	if resumeToken != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(resumeToken)
+		if err != nil {
+			return errBadResumeToken
+		}
+
+		opts.SetResumeAfter(bson.Raw(raw))
+	}
+
+	cs, err := m.collection.Watch(ctx, mongo.Pipeline{}, opts)
+	if err != nil {
+		return changeStreamError(err)
+	}
+	defer cs.Close(context.Background())
+
+	// the authors of the blogs changed in the watch,
+	// for the delete events which have only the blog ID
+	authors := make(map[primitive.ObjectID]string)
+
+	// the blogs moved to the trash in the watch, their purge is not sent again
+	trashed := make(map[primitive.ObjectID]bool)
+
+	for cs.Next(ctx) {
+		change := &mongoChange{}
+
+		if err = cs.Decode(change); err != nil {
+			return fmt.Errorf("error while decoding change: %w", err)
+		}
+
+		ev := &blogEvent{
+			Item:  change.FullDocument,
+			Token: base64.RawURLEncoding.EncodeToString(cs.ResumeToken()),
+			Time:  time.Unix(int64(change.ClusterTime.T), 0).UTC(),
+		}
+
+		switch change.OperationType {
+		case "insert":
+			ev.Type = eventCreated
+		case "update", "replace":
+			switch {
+			case ev.Item != nil && ev.Item.deleted():
+				ev.Type = eventDeleted
+			case change.undeleted():
+				ev.Type = eventCreated
+			default:
+				ev.Type = eventUpdated
+			}
+		case "delete":
+			if trashed[change.DocumentKey.ID] {
+				delete(trashed, change.DocumentKey.ID)
+				delete(authors, change.DocumentKey.ID)
+
+				continue
+			}
+
+			ev.Type = eventDeleted
+		default:
+			continue
+		}
+
+		if ev.Item == nil {
+			// deleted, or deleted before the lookup of the update
+			ev.Item = &blogItem{
+				ID:       change.DocumentKey.ID,
+				AuthorID: authors[change.DocumentKey.ID],
+			}
+		}
+
+		if change.OperationType == "delete" {
+			delete(authors, ev.Item.ID)
+		} else if ev.Item.AuthorID != "" {
+			authors[ev.Item.ID] = ev.Item.AuthorID
+		}
+
+		if ev.Type == eventDeleted && change.OperationType != "delete" {
+			trashed[ev.Item.ID] = true
+		} else {
+			delete(trashed, ev.Item.ID)
+		}
+
+		if err = fn(ev); err != nil {
+			return err
+		}
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return changeStreamError(cs.Err())
+}
+
+// changeStreamError converts the errors of the lost change stream history.
+func changeStreamError(err error) error {
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) {
+		switch cmdErr.Code {
+		case 280, 286: // ChangeStreamFatalError, ChangeStreamHistoryLost
+			return fmt.Errorf("%w: %v", errResumeTokenExpired, err)
+		}
+	}
+
+	return err
+}
+
+// mongoFilter returns the filter of the blogs selected by f.
+func mongoFilter(f listFilter) bson.M {
+	filter := bson.M{}
+
+	if f.AuthorID != "" {
+		filter["author_id"] = f.AuthorID
+	}
+
+	addTimeRange(filter, "create_time", f.CreatedAfter, f.CreatedBefore)
+	addTimeRange(filter, "update_time", f.UpdatedAfter, f.UpdatedBefore)
+
+	if f.Published != nil {
+		filter["published"] = *f.Published
+	}
+
+	if f.Deleted {
+		deleted := bson.M{"$ne": nil}
+		if !f.DeletedBefore.IsZero() {
+			deleted["$lt"] = f.DeletedBefore
+		}
+
+		filter["delete_time"] = deleted
+	} else {
+		filter["delete_time"] = nil
+	}
+
+	if len(f.Tags) > 0 {
+		op := "$in"
+		if f.AllTags {
+			op = "$all"
+		}
+
+		filter["tags"] = bson.M{op: f.Tags}
+	}
+
+	if f.Category != "" {
+		filter["category"] = f.Category
+	}
+
+	return filter
+}
+
+// mongoListFilter returns the filter of the blogs selected by the query.
+func mongoListFilter(q listQuery) (bson.M, error) {
+	filter := mongoFilter(q.listFilter)
+
+	if q.After == nil {
+		return filter, nil
+	}
+
+	oid, err := primitive.ObjectIDFromHex(q.After.ID)
+	if err != nil {
+		return nil, errBadPageToken
+	}
+
+	op := "$gt"
+	if q.Descending {
+		op = "$lt"
+	}
+
+	if q.OrderBy == orderByID {
+		filter["_id"] = bson.M{op: oid}
+
+		return filter, nil
+	}
+
+	key, err := q.OrderBy.value(q.After.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	field := q.OrderBy.field()
+	filter["$or"] = bson.A{
+		bson.M{field: bson.M{op: key}},
+		bson.M{field: key, "_id": bson.M{op: oid}},
+	}
+
+	return filter, nil
+}
+
+// addTimeRange adds the exclusive range of the time field to the filter,
+// a zero bound is not added.
+func addTimeRange(filter bson.M, field string, after, before time.Time) {
+	cond := bson.M{}
+
+	if !after.IsZero() {
+		cond["$gt"] = after
+	}
+
+	if !before.IsZero() {
+		cond["$lt"] = before
+	}
+
+	if len(cond) > 0 {
+		filter[field] = cond
+	}
+}
+
+// writeError converts the error of a write filtered by the ID and the version.
+// When nothing matched the filter it finds out whether the blog is missing
+// or has another version.
+func (m *mongoStore) writeError(ctx context.Context, err error, filter bson.M) error {
+	if _, ok := filter["version"]; !ok || !errors.Is(err, mongo.ErrNoDocuments) {
+		return mongoError(err)
+	}
+
+	found := bson.M{}
+
+	for k, v := range filter {
+		if k != "version" {
+			found[k] = v
+		}
+	}
+
+	n, err := m.collection.CountDocuments(ctx, found)
+	if err != nil {
+		return mongoError(err)
+	}
+
+	if n == 0 {
+		return errBlogNotFound
+	}
+
+	return errVersionMismatch
+}
+
+// commentError converts the driver errors of the comments to the store errors.
+func commentError(err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return errCommentNotFound
+	}
+
+	return mongoError(err)
+}
+
+// mongoError converts the driver errors to the store errors.
+func mongoError(err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return errBlogNotFound
+	}
+
+	if mongo.IsDuplicateKeyError(err) {
+		return errBlogExists
+	}
+
+	if mongo.IsTimeout(err) {
+		return fmt.Errorf("%w: %v", errStoreTimeout, err)
+	}
+
+	return err
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"errors"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// errRevisionNotFound is returned by a BlogStore
+// when the blog has no revision with the requested number.
+var errRevisionNotFound = errors.New("revision not found")
+
+// errDiffTooLarge is returned by lineDiff
+// when the changed lines of the texts are too many to compare.
+var errDiffTooLarge = errors.New("diff too large")
+
+// maxDiffCells limits the size of the table of lineDiff,
+// the product of the numbers of the changed lines of the texts:
+// a thousand lines changed to a thousand others take 8 MB.
+const maxDiffCells = 1 << 20
+
+// blogRevision is a saved state of a blog.
+// The stores save a new revision on every create and update,
+// so the last revision is always the current state of the blog.
+type blogRevision struct {
+	BlogID   primitive.ObjectID `bson:"blog_id"`
+	Number   int64              `bson:"number"`
+	AuthorID string             `bson:"author_id"`
+	Title    string             `bson:"title"`
+	Content  string             `bson:"content"`
+}
+
+// newRevision returns the revision of the current state of the blog.
+func newRevision(data *blogItem) *blogRevision {
+	return &blogRevision{
+		BlogID:   data.ID,
+		Number:   data.Revision,
+		AuthorID: data.AuthorID,
+		Title:    data.Title,
+		Content:  data.Content,
+	}
+}
+
+// diffOp is a kind of the line in a diff.
+type diffOp int
+
+const (
+	diffEqual diffOp = iota
+	diffInsert
+	diffDelete
+)
+
+// diffLine is a line of a diff.
+type diffLine struct {
+	Op   diffOp
+	Text string
+}
+
+// lineDiff returns the line by line difference between the texts.
+// It is based on the longest common subsequence of the lines,
+// it returns errDiffTooLarge when its table would have more than maxDiffCells.
+func lineDiff(from, to string) ([]diffLine, error) {
+	a, b := splitLines(from), splitLines(to)
+
+	// the common prefix and suffix don't need the quadratic part
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ma, mb := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if len(ma) > 0 && len(mb) > maxDiffCells/len(ma) {
+		return nil, errDiffTooLarge
+	}
+
+	diff := make([]diffLine, 0, len(a)+len(b))
+
+	for _, line := range a[:prefix] {
+		diff = append(diff, diffLine{Op: diffEqual, Text: line})
+	}
+
+	// lcs[i][j] is the length of the common subsequence of ma[i:] and mb[j:]
+	lcs := make([][]int, len(ma)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(mb)+1)
+	}
+
+	for i := len(ma) - 1; i >= 0; i-- {
+		for j := len(mb) - 1; j >= 0; j-- {
+			switch {
+			case ma[i] == mb[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(ma) || j < len(mb) {
+		switch {
+		case i < len(ma) && j < len(mb) && ma[i] == mb[j]:
+			diff = append(diff, diffLine{Op: diffEqual, Text: ma[i]})
+			i++
+			j++
+		case i < len(ma) && (j == len(mb) || lcs[i+1][j] >= lcs[i][j+1]):
+			diff = append(diff, diffLine{Op: diffDelete, Text: ma[i]})
+			i++
+		default:
+			diff = append(diff, diffLine{Op: diffInsert, Text: mb[j]})
+			j++
+		}
+	}
+
+	for _, line := range a[len(a)-suffix:] {
+		diff = append(diff, diffLine{Op: diffEqual, Text: line})
+	}
+
+	return diff, nil
+}
+
+// splitLines splits the text into lines, an empty text has no lines.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
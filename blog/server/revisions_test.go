@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLineDiff(t *testing.T) {
+	eq := func(text string) diffLine { return diffLine{Op: diffEqual, Text: text} }
+	ins := func(text string) diffLine { return diffLine{Op: diffInsert, Text: text} }
+	del := func(text string) diffLine { return diffLine{Op: diffDelete, Text: text} }
+
+	tests := []struct {
+		name     string
+		from, to string
+		want     []diffLine
+	}{
+		{"both empty", "", "", []diffLine{}},
+		{"equal", "a\nb", "a\nb", []diffLine{eq("a"), eq("b")}},
+		{"trailing newline ignored", "a\n", "a", []diffLine{eq("a")}},
+		{"from empty", "", "a\nb", []diffLine{ins("a"), ins("b")}},
+		{"to empty", "a\nb", "", []diffLine{del("a"), del("b")}},
+		{"insert in the middle", "a\nc", "a\nb\nc", []diffLine{eq("a"), ins("b"), eq("c")}},
+		{"delete in the middle", "a\nb\nc", "a\nc", []diffLine{eq("a"), del("b"), eq("c")}},
+		{"replace", "a\nb\nc", "a\nx\nc", []diffLine{eq("a"), del("b"), ins("x"), eq("c")}},
+		{
+			"common lines kept",
+			"a\nb\nc\nd",
+			"b\nx\nd\ny",
+			[]diffLine{del("a"), eq("b"), del("c"), ins("x"), eq("d"), ins("y")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := lineDiff(tt.from, tt.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("lineDiff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineDiffLimit(t *testing.T) {
+	lines := func(prefix string, n int) string {
+		var b strings.Builder
+		for i := 0; i < n; i++ {
+			fmt.Fprintf(&b, "%s%d\n", prefix, i)
+		}
+
+		return b.String()
+	}
+
+	tests := []struct {
+		name     string
+		from, to string
+		wantErr  bool
+	}{
+		{"all changed at the limit", lines("a", 1024), lines("b", 1024), false},
+		{"all changed over the limit", lines("a", 1025), lines("b", 1024), true},
+		{"only inserted", "", lines("b", 100000), false},
+		{
+			// the common prefix and suffix are not in the table
+			"few changed in long texts",
+			lines("a", 50000) + "x\n" + lines("c", 50000),
+			lines("a", 50000) + "y\n" + lines("c", 50000),
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := lineDiff(tt.from, tt.to)
+			if tt.wantErr != errors.Is(err, errDiffTooLarge) {
+				t.Errorf("lineDiff() error = %v, want errDiffTooLarge %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// defaultSearchLimit is used when the search request has no limit.
+	defaultSearchLimit = 20
+	// titleWeight makes the words of the title more relevant than the content ones.
+	titleWeight = 2
+	// snippetWidth is the max number of characters in a snippet.
+	snippetWidth = 160
+)
+
+// searchHit is a blog found by BlogStore.Search.
+type searchHit struct {
+	Item  *blogItem
+	Score float64
+}
+
+// tokenize splits the text into lower case words.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), isNotWordRune)
+}
+
+func isNotWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// invertedIndex is an in-process full-text index of the blogs
+// used by the stores which have no text search of their own.
+// It is safe for concurrent use.
+type invertedIndex struct {
+	mu sync.RWMutex
+	// postings keeps the weighted frequency of a word in every blog with it
+	postings map[string]map[primitive.ObjectID]float64
+	// words keeps the words of every blog, so they can be removed
+	words map[primitive.ObjectID][]string
+}
+
+func newInvertedIndex() *invertedIndex {
+	return &invertedIndex{
+		postings: make(map[string]map[primitive.ObjectID]float64),
+		words:    make(map[primitive.ObjectID][]string),
+	}
+}
+
+// Add indexes the blog, replacing its previous version.
+func (x *invertedIndex) Add(item *blogItem) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.remove(item.ID)
+
+	freq := make(map[string]float64)
+
+	for _, word := range tokenize(item.Title) {
+		freq[word] += titleWeight
+	}
+
+	for _, word := range tokenize(item.Content) {
+		freq[word]++
+	}
+
+	words := make([]string, 0, len(freq))
+
+	for word, f := range freq {
+		docs, ok := x.postings[word]
+		if !ok {
+			docs = make(map[primitive.ObjectID]float64)
+			x.postings[word] = docs
+		}
+
+		docs[item.ID] = f
+		words = append(words, word)
+	}
+
+	x.words[item.ID] = words
+}
+
+// Remove drops the blog from the index.
+func (x *invertedIndex) Remove(id primitive.ObjectID) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.remove(id)
+}
+
+func (x *invertedIndex) remove(id primitive.ObjectID) {
+	for _, word := range x.words[id] {
+		delete(x.postings[word], id)
+
+		if len(x.postings[word]) == 0 {
+			delete(x.postings, word)
+		}
+	}
+
+	delete(x.words, id)
+}
+
+// indexHit is a blog ID found by invertedIndex.Search.
+type indexHit struct {
+	ID    primitive.ObjectID
+	Score float64
+}
+
+// Search returns up to limit blogs having any word of the query,
+// the most relevant first. Relevance is the sum of tf-idf of the found words.
+func (x *invertedIndex) Search(query string, limit int) []indexHit {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	total := float64(len(x.words))
+	scores := make(map[primitive.ObjectID]float64)
+
+	for _, word := range uniqueWords(query) {
+		docs := x.postings[word]
+		if len(docs) == 0 {
+			continue
+		}
+
+		idf := math.Log(1 + total/float64(len(docs)))
+
+		for id, f := range docs {
+			// damp the long documents with a lot of repeats
+			scores[id] += idf * (1 + math.Log(f))
+		}
+	}
+
+	hits := make([]indexHit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, indexHit{ID: id, Score: score})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score == hits[j].Score {
+			return hits[i].ID.Hex() < hits[j].ID.Hex()
+		}
+
+		return hits[i].Score > hits[j].Score
+	})
+
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	return hits
+}
+
+// uniqueWords returns the words of the text without repeats.
+func uniqueWords(text string) []string {
+	seen := make(map[string]bool)
+	words := []string{}
+
+	for _, word := range tokenize(text) {
+		if !seen[word] {
+			seen[word] = true
+			words = append(words, word)
+		}
+	}
+
+	return words
+}
+
+// snippet returns the part of the text around the first found word
+// with all the found words wrapped in <em></em>.
+// It returns the beginning of the text when there are no found words.
+func snippet(text string, words []string) string {
+	found := make(map[string]bool, len(words))
+	for _, word := range words {
+		found[word] = true
+	}
+
+	type span struct{ start, end int }
+
+	runes := []rune(text)
+	matches := []span{}
+
+	for i := 0; i < len(runes); {
+		if isNotWordRune(runes[i]) {
+			i++
+
+			continue
+		}
+
+		j := i
+		for j < len(runes) && !isNotWordRune(runes[j]) {
+			j++
+		}
+
+		if found[strings.ToLower(string(runes[i:j]))] {
+			matches = append(matches, span{i, j})
+		}
+
+		i = j
+	}
+
+	// start a bit before the first found word to show its context
+	start := 0
+	if len(matches) > 0 && matches[0].start > snippetWidth/4 {
+		start = matches[0].start - snippetWidth/4
+	}
+
+	end := start + snippetWidth
+	if end > len(runes) {
+		end = len(runes)
+	}
+
+	var b strings.Builder
+
+	if start > 0 {
+		b.WriteString("...")
+	}
+
+	pos := start
+
+	for _, m := range matches {
+		if m.start < start {
+			continue
+		}
+
+		if m.end > end {
+			break
+		}
+
+		b.WriteString(string(runes[pos:m.start]))
+		b.WriteString("<em>")
+		b.WriteString(string(runes[m.start:m.end]))
+		b.WriteString("</em>")
+
+		pos = m.end
+	}
+
+	b.WriteString(string(runes[pos:end]))
+
+	if end < len(runes) {
+		b.WriteString("...")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"", []string{}},
+		{"Hello, gRPC-Go world!", []string{"hello", "grpc", "go", "world"}},
+		{"Привет мир 2024", []string{"привет", "мир", "2024"}},
+	}
+
+	for _, tt := range tests {
+		if got := tokenize(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("tokenize(%q) = %#v, want %#v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestInvertedIndexSearch(t *testing.T) {
+	ids := []primitive.ObjectID{{11: 1}, {11: 2}, {11: 3}}
+
+	x := newInvertedIndex()
+	x.Add(&blogItem{ID: ids[0], Title: "Streaming in gRPC", Content: "server streams and client streams"})
+	x.Add(&blogItem{ID: ids[1], Title: "Go generics", Content: "generics in go, go, go"})
+	x.Add(&blogItem{ID: ids[2], Title: "Cooking", Content: "a recipe with grpc mentioned once"})
+
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []primitive.ObjectID
+	}{
+		{"no words", "", 0, []primitive.ObjectID{}},
+		{"unknown word", "rust", 0, []primitive.ObjectID{}},
+		{"title weighs more", "grpc", 0, []primitive.ObjectID{ids[0], ids[2]}},
+		{"case insensitive", "GENERICS", 0, []primitive.ObjectID{ids[1]}},
+		{"any word", "generics recipe", 0, []primitive.ObjectID{ids[1], ids[2]}},
+		{"repeated query words count once", "go go go", 0, []primitive.ObjectID{ids[1]}},
+		{"limit", "grpc", 1, []primitive.ObjectID{ids[0]}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []primitive.ObjectID{}
+			for _, hit := range x.Search(tt.query, tt.limit) {
+				got = append(got, hit.ID)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvertedIndexUpdateAndRemove(t *testing.T) {
+	id := primitive.ObjectID{11: 1}
+
+	x := newInvertedIndex()
+	x.Add(&blogItem{ID: id, Title: "old title"})
+	x.Add(&blogItem{ID: id, Title: "new title"})
+
+	if hits := x.Search("old", 0); len(hits) != 0 {
+		t.Errorf("the replaced words are found: %v", hits)
+	}
+
+	if hits := x.Search("new", 0); len(hits) != 1 {
+		t.Errorf("the new words are not found: %v", hits)
+	}
+
+	x.Remove(id)
+
+	if hits := x.Search("new title", 0); len(hits) != 0 {
+		t.Errorf("the removed blog is found: %v", hits)
+	}
+
+	if len(x.postings) != 0 || len(x.words) != 0 {
+		t.Errorf("the index keeps the removed blog: %v %v", x.postings, x.words)
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	long := strings.Repeat("filler ", 30) + "the grpc word" + strings.Repeat(" tail", 40)
+	at := strings.Index(long, "grpc")
+	start := at - snippetWidth/4
+
+	tests := []struct {
+		name  string
+		text  string
+		words []string
+		want  string
+	}{
+		{"no match", "short text", []string{"grpc"}, "short text"},
+		{"all matches", "gRPC and grpc.", []string{"grpc"}, "<em>gRPC</em> and <em>grpc</em>."},
+		{"whole words only", "grpcs are not grpc", []string{"grpc"}, "grpcs are not <em>grpc</em>"},
+		{"no match in long text", long, nil, long[:snippetWidth] + "..."},
+		{
+			"context before the first match",
+			long,
+			[]string{"grpc"},
+			"..." + long[start:at] + "<em>grpc</em>" + long[at+len("grpc"):start+snippetWidth] + "...",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := snippet(tt.text, tt.words); got != tt.want {
+				t.Errorf("snippet() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,974 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// sqlMigrations are applied in order at startup.
+// The number of applied ones is kept in the schema_migrations table,
+// so never change or reorder them, only append new ones.
+var sqlMigrations = []string{
+	`CREATE TABLE blogs (
+		id        TEXT PRIMARY KEY,
+		author_id TEXT NOT NULL DEFAULT '',
+		title     TEXT NOT NULL DEFAULT '',
+		content   TEXT NOT NULL DEFAULT ''
+	)`,
+	`ALTER TABLE blogs ADD COLUMN revision INTEGER NOT NULL DEFAULT 1;
+	CREATE TABLE blog_revisions (
+		blog_id   TEXT NOT NULL REFERENCES blogs (id) ON DELETE CASCADE,
+		number    INTEGER NOT NULL,
+		author_id TEXT NOT NULL DEFAULT '',
+		title     TEXT NOT NULL DEFAULT '',
+		content   TEXT NOT NULL DEFAULT '',
+		PRIMARY KEY (blog_id, number)
+	);
+	INSERT INTO blog_revisions (blog_id, number, author_id, title, content)
+		SELECT id, revision, author_id, title, content FROM blogs`,
+	`ALTER TABLE blogs ADD COLUMN version INTEGER NOT NULL DEFAULT 1`,
+	`ALTER TABLE blogs ADD COLUMN create_time INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE blogs ADD COLUMN update_time INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE blogs ADD COLUMN published INTEGER NOT NULL DEFAULT 0`,
+	`ALTER TABLE blogs ADD COLUMN delete_time INTEGER NOT NULL DEFAULT 0`,
+	`ALTER TABLE blogs ADD COLUMN tags TEXT NOT NULL DEFAULT '[]';
+	ALTER TABLE blogs ADD COLUMN category TEXT NOT NULL DEFAULT ''`,
+	`CREATE TABLE comments (
+		id          TEXT PRIMARY KEY,
+		blog_id     TEXT NOT NULL REFERENCES blogs (id) ON DELETE CASCADE,
+		parent_id   TEXT REFERENCES comments (id) ON DELETE CASCADE,
+		author_id   TEXT NOT NULL DEFAULT '',
+		content     TEXT NOT NULL DEFAULT '',
+		state       INTEGER NOT NULL DEFAULT 0,
+		create_time INTEGER NOT NULL DEFAULT 0,
+		update_time INTEGER NOT NULL DEFAULT 0
+	);
+	CREATE INDEX comments_blog ON comments (blog_id, id);
+	CREATE INDEX comments_parent ON comments (parent_id)`,
+}
+
+// sqlStore keeps blogs and comments in an embedded SQLite database file.
+// IDs are stored as hex ObjectIDs, so they look the same as the Mongo ones,
+// times are stored as Unix milliseconds, tags as a JSON array.
+// The full-text search uses an in-process index which is built at startup,
+// the changes are watched with an in-process event bus.
+type sqlStore struct {
+	db     *sql.DB
+	index  *invertedIndex
+	events *eventBus
+
+	// mu serializes the writes, so the index and the watchers
+	// get the changes in the commit order
+	mu sync.Mutex
+}
+
+// newSQLStore opens the SQLite database at path
+// and brings its schema up to date.
+func newSQLStore(path string) (*sqlStore, error) {
+	db, err := sql.Open("sqlite3", path+"?_foreign_keys=on&_busy_timeout=5000")
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite allows only one writer at a time
+	db.SetMaxOpenConns(1)
+
+	s := &sqlStore{
+		db:     db,
+		index:  newInvertedIndex(),
+		events: newEventBus(),
+	}
+
+	if err = s.migrate(context.Background()); err != nil {
+		db.Close()
+
+		return nil, fmt.Errorf("cannot migrate %s: %w", path, err)
+	}
+
+	err = s.List(context.Background(), listQuery{}, func(item *blogItem) error {
+		s.index.Add(item)
+
+		return nil
+	})
+	if err != nil {
+		db.Close()
+
+		return nil, fmt.Errorf("cannot index %s: %w", path, err)
+	}
+
+	return s, nil
+}
+
+// migrate applies the sqlMigrations which were not applied yet.
+func (s *sqlStore) migrate(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx,
+		`CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER NOT NULL)`)
+	if err != nil {
+		return err
+	}
+
+	var version int
+
+	err = s.db.QueryRowContext(ctx,
+		`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version)
+	if err != nil {
+		return err
+	}
+
+	for ; version < len(sqlMigrations); version++ {
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		if _, err = tx.ExecContext(ctx, sqlMigrations[version]); err != nil {
+			tx.Rollback()
+
+			return fmt.Errorf("migration %d: %w", version+1, err)
+		}
+
+		_, err = tx.ExecContext(ctx, `INSERT INTO schema_migrations (version) VALUES (?)`, version+1)
+		if err != nil {
+			tx.Rollback()
+
+			return err
+		}
+
+		if err = tx.Commit(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close closes the database.
+func (s *sqlStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *sqlStore) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+func (s *sqlStore) Create(ctx context.Context, item *blogItem) (*blogItem, error) {
+	data := newBlogData(item)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+	defer tx.Rollback() //nolint
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO blogs (`+sqlBlogColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		data.ID.Hex(), data.AuthorID, data.Title, data.Content, data.Revision, data.Version,
+		sqlTime(data.CreateTime), sqlTime(data.UpdateTime), data.Published, sqlTime(data.DeleteTime),
+		sqlTags(data.Tags), data.Category,
+	)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+
+	if err = insertRevision(ctx, tx, &data); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, sqlError(err)
+	}
+
+	s.index.Add(&data)
+	s.events.Publish(eventCreated, &data)
+
+	return &data, nil
+}
+
+func (s *sqlStore) Read(ctx context.Context, id primitive.ObjectID) (*blogItem, error) {
+	data, err := readBlog(ctx, s.db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.deleted() {
+		return nil, errBlogNotFound
+	}
+
+	return data, nil
+}
+
+func (s *sqlStore) Author(ctx context.Context, id primitive.ObjectID) (string, error) {
+	var author string
+
+	err := s.db.QueryRowContext(ctx, `SELECT author_id FROM blogs WHERE id = ?`, id.Hex()).Scan(&author)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", errBlogNotFound
+	}
+
+	if err != nil {
+		return "", sqlError(err)
+	}
+
+	return author, nil
+}
+
+func (s *sqlStore) Update(ctx context.Context, item *blogItem, version int64) (*blogItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+	defer tx.Rollback() //nolint
+
+	data, err := readBlog(ctx, tx, item.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.deleted() {
+		return nil, errBlogNotFound
+	}
+
+	if version != 0 && data.Version != version {
+		return nil, errVersionMismatch
+	}
+
+	data.AuthorID = item.AuthorID
+	data.Title = item.Title
+	data.Content = item.Content
+	data.Published = item.Published
+	data.Tags = item.Tags
+	data.Category = item.Category
+	data.Revision++
+	data.Version++
+	data.UpdateTime = storeNow()
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE blogs SET author_id = ?, title = ?, content = ?, published = ?, tags = ?, category = ?,
+			revision = ?, version = ?, update_time = ? WHERE id = ?`,
+		data.AuthorID, data.Title, data.Content, data.Published, sqlTags(data.Tags), data.Category,
+		data.Revision, data.Version, sqlTime(data.UpdateTime), data.ID.Hex(),
+	)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+
+	if err = insertRevision(ctx, tx, data); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, sqlError(err)
+	}
+
+	s.index.Add(data)
+	s.events.Publish(eventUpdated, data)
+
+	return data, nil
+}
+
+func (s *sqlStore) Delete(ctx context.Context, id primitive.ObjectID, version int64) (*blogItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+	defer tx.Rollback() //nolint
+
+	data, err := readBlog(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if version != 0 && data.Version != version {
+		return nil, errVersionMismatch
+	}
+
+	// the revisions and the comments are deleted by the foreign key cascade
+	if _, err = tx.ExecContext(ctx, `DELETE FROM blogs WHERE id = ?`, id.Hex()); err != nil {
+		return nil, sqlError(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, sqlError(err)
+	}
+
+	s.index.Remove(id)
+
+	// the watchers were told of the soft delete of the blog in the trash
+	if !data.deleted() {
+		s.events.Publish(eventDeleted, data)
+	}
+
+	return data, nil
+}
+
+func (s *sqlStore) SoftDelete(ctx context.Context, id primitive.ObjectID, version int64) (*blogItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := readBlog(ctx, s.db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.deleted() {
+		return nil, errBlogNotFound
+	}
+
+	if version != 0 && data.Version != version {
+		return nil, errVersionMismatch
+	}
+
+	data.Version++
+	data.DeleteTime = storeNow()
+
+	// the writes are serialized, so the blog is the same as read
+	_, err = s.db.ExecContext(ctx,
+		`UPDATE blogs SET version = ?, delete_time = ? WHERE id = ?`,
+		data.Version, sqlTime(data.DeleteTime), id.Hex(),
+	)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+
+	s.index.Remove(id)
+	s.events.Publish(eventDeleted, data)
+
+	return data, nil
+}
+
+func (s *sqlStore) Undelete(ctx context.Context, id primitive.ObjectID) (*blogItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := readBlog(ctx, s.db, id)
+	if err != nil || !data.deleted() {
+		return data, err
+	}
+
+	data.Version++
+	data.DeleteTime = time.Time{}
+
+	_, err = s.db.ExecContext(ctx,
+		`UPDATE blogs SET version = ?, delete_time = 0 WHERE id = ?`,
+		data.Version, id.Hex(),
+	)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+
+	s.index.Add(data)
+	s.events.Publish(eventCreated, data)
+
+	return data, nil
+}
+
+func (s *sqlStore) ListRevisions(ctx context.Context, id primitive.ObjectID) ([]*blogRevision, error) {
+	if _, err := s.Read(ctx, id); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+sqlRevisionColumns+` FROM blog_revisions WHERE blog_id = ? ORDER BY number`,
+		id.Hex(),
+	)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+	defer rows.Close()
+
+	revisions := []*blogRevision{}
+
+	for rows.Next() {
+		rev, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		revisions = append(revisions, rev)
+	}
+
+	return revisions, rows.Err()
+}
+
+func (s *sqlStore) ReadRevision(
+	ctx context.Context,
+	id primitive.ObjectID,
+	number int64,
+) (*blogRevision, error) {
+	if _, err := s.Read(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return scanRevision(s.db.QueryRowContext(ctx,
+		`SELECT `+sqlRevisionColumns+` FROM blog_revisions WHERE blog_id = ? AND number = ?`,
+		id.Hex(), number,
+	))
+}
+
+func (s *sqlStore) List(ctx context.Context, q listQuery, fn func(item *blogItem) error) error {
+	query, args, err := sqlListQuery(q)
+	if err != nil {
+		return err
+	}
+
+	items, err := s.queryBlogs(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	for _, data := range items {
+		if err = fn(data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// queryBlogs reads all the blogs of the query before they are handed to a callback:
+// the store has a single connection, a callback sending to a slow stream
+// must not keep it from the other calls.
+func (s *sqlStore) queryBlogs(ctx context.Context, query string, args ...interface{}) ([]*blogItem, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+	defer rows.Close()
+
+	var items []*blogItem
+
+	for rows.Next() {
+		data, err := scanBlog(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error while decoding data: %w", err)
+		}
+
+		items = append(items, data)
+	}
+
+	return items, rows.Err()
+}
+
+func (s *sqlStore) ListTags(ctx context.Context, f listFilter) ([]tagCount, error) {
+	where, args := sqlWhere(f)
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT t.value, COUNT(*) FROM blogs, json_each(blogs.tags) AS t
+			WHERE `+strings.Join(where, " AND ")+`
+			GROUP BY t.value ORDER BY COUNT(*) DESC, t.value`,
+		args...,
+	)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+	defer rows.Close()
+
+	counts := []tagCount{}
+
+	for rows.Next() {
+		var c tagCount
+		if err = rows.Scan(&c.Tag, &c.Count); err != nil {
+			return nil, err
+		}
+
+		counts = append(counts, c)
+	}
+
+	return counts, rows.Err()
+}
+
+func (s *sqlStore) Search(ctx context.Context, query string, limit int) ([]searchHit, error) {
+	hits := []searchHit{}
+
+	for _, hit := range s.index.Search(query, limit) {
+		data, err := s.Read(ctx, hit.ID)
+		if errors.Is(err, errBlogNotFound) {
+			// deleted after the search
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		hits = append(hits, searchHit{Item: data, Score: hit.Score})
+	}
+
+	return hits, nil
+}
+
+func (s *sqlStore) Watch(ctx context.Context, resumeToken string, fn func(ev *blogEvent) error) error {
+	return s.events.Watch(ctx, resumeToken, fn)
+}
+
+func (s *sqlStore) CreateComment(ctx context.Context, item *commentItem) (*commentItem, error) {
+	data := newCommentData(item)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO comments (`+sqlCommentColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		data.ID.Hex(), data.BlogID.Hex(), sqlParentID(data.ParentID), data.AuthorID, data.Content,
+		data.State, sqlTime(data.CreateTime), sqlTime(data.UpdateTime),
+	)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+
+	return &data, nil
+}
+
+func (s *sqlStore) ReadComment(ctx context.Context, id primitive.ObjectID) (*commentItem, error) {
+	return scanComment(s.db.QueryRowContext(ctx,
+		`SELECT `+sqlCommentColumns+` FROM comments WHERE id = ?`,
+		id.Hex(),
+	))
+}
+
+func (s *sqlStore) UpdateComment(ctx context.Context, item *commentItem) (*commentItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := s.ReadComment(ctx, item.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	data.Content = item.Content
+	data.State = item.State
+	data.UpdateTime = storeNow()
+
+	_, err = s.db.ExecContext(ctx,
+		`UPDATE comments SET content = ?, state = ?, update_time = ? WHERE id = ?`,
+		data.Content, data.State, sqlTime(data.UpdateTime), data.ID.Hex(),
+	)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+
+	return data, nil
+}
+
+func (s *sqlStore) DeleteComment(ctx context.Context, id primitive.ObjectID) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the cascade doesn't count the deleted replies, so count them before
+	var n int
+
+	err := s.db.QueryRowContext(ctx,
+		`WITH RECURSIVE thread (id) AS (
+			SELECT id FROM comments WHERE id = ?
+			UNION ALL
+			SELECT comments.id FROM comments JOIN thread ON comments.parent_id = thread.id
+		) SELECT COUNT(*) FROM thread`,
+		id.Hex(),
+	).Scan(&n)
+	if err != nil {
+		return 0, sqlError(err)
+	}
+
+	if n == 0 {
+		return 0, errCommentNotFound
+	}
+
+	// the replies are deleted by the foreign key cascade
+	if _, err = s.db.ExecContext(ctx, `DELETE FROM comments WHERE id = ?`, id.Hex()); err != nil {
+		return 0, sqlError(err)
+	}
+
+	return n, nil
+}
+
+func (s *sqlStore) ListComments(
+	ctx context.Context,
+	q commentQuery,
+	fn func(item *commentItem) error,
+) error {
+	where := []string{"blog_id = ?"}
+	args := []interface{}{q.BlogID.Hex()}
+
+	if len(q.States) > 0 {
+		where = append(where, "state IN ("+strings.TrimSuffix(strings.Repeat("?, ", len(q.States)), ", ")+")")
+		for _, state := range q.States {
+			args = append(args, state)
+		}
+	}
+
+	if q.After != nil {
+		where = append(where, "id > ?")
+		args = append(args, q.After.Hex())
+	}
+
+	query := "SELECT " + sqlCommentColumns + " FROM comments WHERE " + strings.Join(where, " AND ") +
+		" ORDER BY id"
+	if q.Limit > 0 {
+		query += " LIMIT " + strconv.Itoa(q.Limit)
+	}
+
+	items, err := s.queryComments(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	for _, data := range items {
+		if err = fn(data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// queryComments reads all the comments of the query, as queryBlogs does.
+func (s *sqlStore) queryComments(ctx context.Context, query string, args ...interface{}) ([]*commentItem, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+	defer rows.Close()
+
+	var items []*commentItem
+
+	for rows.Next() {
+		data, err := scanComment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error while decoding data: %w", err)
+		}
+
+		items = append(items, data)
+	}
+
+	return items, rows.Err()
+}
+
+// sqlListQuery builds the SELECT of the blogs selected by the query.
+func sqlListQuery(q listQuery) (string, []interface{}, error) {
+	where, args := sqlWhere(q.listFilter)
+
+	column, op, dir := "id", ">", "ASC"
+	if q.OrderBy != orderByID {
+		column = q.OrderBy.field()
+	}
+
+	if q.Descending {
+		op, dir = "<", "DESC"
+	}
+
+	if q.After != nil {
+		if q.OrderBy == orderByID {
+			where = append(where, "id "+op+" ?")
+			args = append(args, q.After.ID)
+		} else {
+			key, err := q.OrderBy.value(q.After.Key)
+			if err != nil {
+				return "", nil, err
+			}
+
+			if t, ok := key.(time.Time); ok {
+				key = sqlTime(t)
+			}
+
+			where = append(where, "("+column+" "+op+" ? OR ("+column+" = ? AND id "+op+" ?))")
+			args = append(args, key, key, q.After.ID)
+		}
+	}
+
+	query := "SELECT " + sqlBlogColumns + " FROM blogs WHERE " + strings.Join(where, " AND ")
+
+	query += " ORDER BY " + column + " " + dir
+	if q.OrderBy != orderByID {
+		query += ", id " + dir
+	}
+
+	if q.Limit > 0 {
+		query += " LIMIT " + strconv.Itoa(q.Limit)
+	}
+
+	return query, args, nil
+}
+
+// sqlWhere returns the conditions of the blogs selected by f and their arguments,
+// there is at least one condition.
+func sqlWhere(f listFilter) ([]string, []interface{}) {
+	var (
+		where []string
+		args  []interface{}
+	)
+
+	if f.AuthorID != "" {
+		where = append(where, "author_id = ?")
+		args = append(args, f.AuthorID)
+	}
+
+	for _, cond := range []struct {
+		expr string
+		t    time.Time
+	}{
+		{"create_time > ?", f.CreatedAfter},
+		{"create_time < ?", f.CreatedBefore},
+		{"update_time > ?", f.UpdatedAfter},
+		{"update_time < ?", f.UpdatedBefore},
+	} {
+		if !cond.t.IsZero() {
+			where = append(where, cond.expr)
+			args = append(args, sqlTime(cond.t))
+		}
+	}
+
+	if f.Published != nil {
+		where = append(where, "published = ?")
+		args = append(args, *f.Published)
+	}
+
+	if f.Deleted {
+		where = append(where, "delete_time <> 0")
+	} else {
+		where = append(where, "delete_time = 0")
+	}
+
+	if !f.DeletedBefore.IsZero() {
+		where = append(where, "delete_time < ?")
+		args = append(args, sqlTime(f.DeletedBefore))
+	}
+
+	if len(f.Tags) > 0 {
+		in := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(f.Tags)), ", ") + ")"
+		for _, tag := range f.Tags {
+			args = append(args, tag)
+		}
+
+		if f.AllTags {
+			where = append(where, "(SELECT COUNT(DISTINCT value) FROM json_each(blogs.tags) WHERE value IN "+in+") = ?")
+			args = append(args, len(f.Tags))
+		} else {
+			where = append(where, "EXISTS (SELECT 1 FROM json_each(blogs.tags) WHERE value IN "+in+")")
+		}
+	}
+
+	if f.Category != "" {
+		where = append(where, "category = ?")
+		args = append(args, f.Category)
+	}
+
+	return where, args
+}
+
+// sqlQuerier is implemented by both *sql.DB and *sql.Tx.
+type sqlQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// sqlBlogColumns are the columns read by scanBlog.
+const sqlBlogColumns = "id, author_id, title, content, revision, version, " +
+	"create_time, update_time, published, delete_time, tags, category"
+
+// readBlog reads the blog by its ID.
+func readBlog(ctx context.Context, q sqlQuerier, id primitive.ObjectID) (*blogItem, error) {
+	return scanBlog(q.QueryRowContext(ctx,
+		`SELECT `+sqlBlogColumns+` FROM blogs WHERE id = ?`,
+		id.Hex(),
+	))
+}
+
+// scanBlog reads a blog selected as sqlBlogColumns.
+func scanBlog(row rowScanner) (*blogItem, error) {
+	var (
+		id, tags                           string
+		createTime, updateTime, deleteTime int64
+		data                               blogItem
+	)
+
+	err := row.Scan(&id, &data.AuthorID, &data.Title, &data.Content, &data.Revision, &data.Version,
+		&createTime, &updateTime, &data.Published, &deleteTime, &tags, &data.Category)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errBlogNotFound
+		}
+
+		return nil, err
+	}
+
+	data.ID, err = primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	data.CreateTime = timeFromSQL(createTime)
+	data.UpdateTime = timeFromSQL(updateTime)
+	data.DeleteTime = timeFromSQL(deleteTime)
+
+	if err = json.Unmarshal([]byte(tags), &data.Tags); err != nil {
+		return nil, fmt.Errorf("cannot parse tags: %w", err)
+	}
+
+	return &data, nil
+}
+
+// sqlError converts the driver errors to the store errors.
+func sqlError(err error) error {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return err
+	}
+
+	switch {
+	case sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey,
+		sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique:
+		return errBlogExists
+	case sqliteErr.Code == sqlite3.ErrBusy, sqliteErr.Code == sqlite3.ErrLocked:
+		// the busy timeout is over
+		return fmt.Errorf("%w: %v", errStoreTimeout, err)
+	default:
+		return err
+	}
+}
+
+// sqlTime converts the time to Unix milliseconds, the zero time is 0.
+func sqlTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// sqlTags converts the tags to a JSON array.
+func sqlTags(tags []string) string {
+	if len(tags) == 0 {
+		return "[]"
+	}
+
+	b, err := json.Marshal(tags)
+	if err != nil {
+		// can't happen, the tags are strings
+		panic(err)
+	}
+
+	return string(b)
+}
+
+// timeFromSQL converts Unix milliseconds to time, 0 is the zero time.
+func timeFromSQL(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC()
+}
+
+// sqlRevisionColumns are the columns read by scanRevision.
+const sqlRevisionColumns = "blog_id, number, author_id, title, content"
+
+// insertRevision saves the current state of the blog as its revision.
+func insertRevision(ctx context.Context, tx *sql.Tx, data *blogItem) error {
+	rev := newRevision(data)
+
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO blog_revisions (`+sqlRevisionColumns+`) VALUES (?, ?, ?, ?, ?)`,
+		rev.BlogID.Hex(), rev.Number, rev.AuthorID, rev.Title, rev.Content,
+	)
+
+	return err
+}
+
+// scanRevision reads a revision selected as sqlRevisionColumns.
+func scanRevision(row rowScanner) (*blogRevision, error) {
+	var (
+		id  string
+		rev blogRevision
+	)
+
+	err := row.Scan(&id, &rev.Number, &rev.AuthorID, &rev.Title, &rev.Content)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errRevisionNotFound
+		}
+
+		return nil, err
+	}
+
+	rev.BlogID, err = primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rev, nil
+}
+
+// sqlCommentColumns are the columns read by scanComment.
+const sqlCommentColumns = "id, blog_id, parent_id, author_id, content, state, create_time, update_time"
+
+// sqlParentID converts the parent ID of a comment to a column value,
+// the zero ID of a top level comment is NULL.
+func sqlParentID(id primitive.ObjectID) interface{} {
+	if id.IsZero() {
+		return nil
+	}
+
+	return id.Hex()
+}
+
+// scanComment reads a comment selected as sqlCommentColumns.
+func scanComment(row rowScanner) (*commentItem, error) {
+	var (
+		id, blogID             string
+		parentID               sql.NullString
+		createTime, updateTime int64
+		data                   commentItem
+	)
+
+	err := row.Scan(&id, &blogID, &parentID, &data.AuthorID, &data.Content, &data.State,
+		&createTime, &updateTime)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errCommentNotFound
+		}
+
+		return nil, err
+	}
+
+	if data.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return nil, err
+	}
+
+	if data.BlogID, err = primitive.ObjectIDFromHex(blogID); err != nil {
+		return nil, err
+	}
+
+	if parentID.Valid {
+		if data.ParentID, err = primitive.ObjectIDFromHex(parentID.String); err != nil {
+			return nil, err
+		}
+	}
+
+	data.CreateTime = timeFromSQL(createTime)
+	data.UpdateTime = timeFromSQL(updateTime)
+
+	return &data, nil
+}
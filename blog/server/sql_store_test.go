@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestSQLStore(t *testing.T) *sqlStore {
+	t.Helper()
+
+	s, err := newSQLStore(filepath.Join(t.TempDir(), "blog.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { s.Close() })
+
+	return s
+}
+
+// The callbacks of List and ListComments send to the streams,
+// a slow stream must not hold the connection of the other calls.
+func TestSQLStoreCallbacksDontHoldConnection(t *testing.T) {
+	s := newTestSQLStore(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	blog, err := s.Create(ctx, &blogItem{AuthorID: "a", Title: "t", Content: "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = s.Create(ctx, &blogItem{AuthorID: "b", Title: "t", Content: "c"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = s.CreateComment(ctx, &commentItem{BlogID: blog.ID, AuthorID: "b", Content: "c"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		list func(fn func() error) error
+	}{
+		{"List", func(fn func() error) error {
+			return s.List(ctx, listQuery{}, func(*blogItem) error { return fn() })
+		}},
+		{"ListComments", func(fn func() error) error {
+			return s.ListComments(ctx, commentQuery{BlogID: blog.ID}, func(*commentItem) error { return fn() })
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+
+			err := tt.list(func() error {
+				calls++
+
+				if err := s.Ping(ctx); err != nil {
+					return err
+				}
+
+				_, err := s.Read(ctx, blog.ID)
+
+				return err
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if calls == 0 {
+				t.Error("the callback was not called")
+			}
+		})
+	}
+}
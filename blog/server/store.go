@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// errBlogNotFound is returned by a BlogStore
+// when there is no blog with the requested ID.
+var errBlogNotFound = errors.New("blog not found")
+
+// errBlogExists is returned by a BlogStore
+// when a blog is created with the ID of an existing one.
+var errBlogExists = errors.New("blog already exists")
+
+// errVersionMismatch is returned by a BlogStore
+// when the blog was changed since the expected version.
+var errVersionMismatch = errors.New("blog version mismatch")
+
+// errStoreTimeout is returned by a BlogStore
+// when the database doesn't respond in time.
+var errStoreTimeout = errors.New("store timeout")
+
+// storeNow returns the time of a write.
+// It is rounded to milliseconds as MongoDB keeps the times.
+func storeNow() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
+
+// newBlogData returns the first version of the created blog.
+// The ID and the times of the item are kept when they are set,
+// so the imported blogs keep them.
+func newBlogData(item *blogItem) blogItem {
+	data := *item
+	data.Revision = 1
+	data.Version = 1
+
+	if data.ID.IsZero() {
+		data.ID = primitive.NewObjectID()
+	}
+
+	if data.CreateTime.IsZero() {
+		data.CreateTime = storeNow()
+	}
+
+	if data.UpdateTime.IsZero() {
+		data.UpdateTime = data.CreateTime
+	}
+
+	return data
+}
+
+// BlogStore is a storage of the blogs used by the BlogService handlers.
+//
+// A soft deleted blog stays in the store with its delete time set.
+// It is not found by Read, Update, Search and the revision methods
+// and is listed only by the queries of the deleted blogs,
+// until it is undeleted or removed by Delete.
+type BlogStore interface {
+	// Create stores a new blog and returns it with the assigned ID.
+	// The blog gets its first revision and version, its times are set to now.
+	// See newBlogData for the ID and the times set by the caller.
+	Create(ctx context.Context, item *blogItem) (*blogItem, error)
+
+	// Read returns the blog with the given ID.
+	Read(ctx context.Context, id primitive.ObjectID) (*blogItem, error)
+
+	// Author returns the author ID of the blog with the given ID,
+	// a soft deleted blog is found as well.
+	Author(ctx context.Context, id primitive.ObjectID) (string, error)
+
+	// Update replaces the blog with the same ID and returns the updated blog.
+	// The updated blog is saved as its next revision, its update time is set to now.
+	// A non zero version must match the current version of the blog.
+	Update(ctx context.Context, item *blogItem, version int64) (*blogItem, error)
+
+	// Delete removes the blog with the given ID and its revisions
+	// and returns the removed blog. A soft deleted blog is removed as well.
+	// A non zero version must match the current version of the blog.
+	Delete(ctx context.Context, id primitive.ObjectID, version int64) (*blogItem, error)
+
+	// SoftDelete sets the delete time of the blog to now
+	// and returns the deleted blog.
+	// A non zero version must match the current version of the blog.
+	SoftDelete(ctx context.Context, id primitive.ObjectID, version int64) (*blogItem, error)
+
+	// Undelete clears the delete time of the blog and returns the blog.
+	// A blog which is not deleted is returned as it is.
+	Undelete(ctx context.Context, id primitive.ObjectID) (*blogItem, error)
+
+	// List calls fn for every blog selected by the query
+	// in the query order until fn returns an error.
+	List(ctx context.Context, q listQuery, fn func(item *blogItem) error) error
+
+	// ListTags counts the tags of the blogs selected by the filter,
+	// the most used first.
+	ListTags(ctx context.Context, f listFilter) ([]tagCount, error)
+
+	// Search returns up to limit blogs having the words of the query
+	// in the title or the content, the most relevant first.
+	Search(ctx context.Context, query string, limit int) ([]searchHit, error)
+
+	// ListRevisions returns all the revisions of the blog, the oldest first.
+	ListRevisions(ctx context.Context, id primitive.ObjectID) ([]*blogRevision, error)
+
+	// ReadRevision returns the revision of the blog by its number.
+	ReadRevision(ctx context.Context, id primitive.ObjectID, number int64) (*blogRevision, error)
+
+	// Watch calls fn for every change of the blogs after the resume token,
+	// for the new changes only when the token is empty.
+	// It blocks until the context is done or fn returns an error.
+	Watch(ctx context.Context, resumeToken string, fn func(ev *blogEvent) error) error
+}
+
+// CommentStore is a storage of the comments used by the CommentService handlers.
+// The comments of a blog are deleted with the blog by BlogStore.Delete.
+type CommentStore interface {
+	// CreateComment stores a new comment and returns it with the assigned ID,
+	// its times are set to now.
+	CreateComment(ctx context.Context, item *commentItem) (*commentItem, error)
+
+	// ReadComment returns the comment with the given ID.
+	ReadComment(ctx context.Context, id primitive.ObjectID) (*commentItem, error)
+
+	// UpdateComment replaces the content and the state of the comment
+	// with the same ID and returns the updated comment.
+	// Its update time is set to now.
+	UpdateComment(ctx context.Context, item *commentItem) (*commentItem, error)
+
+	// DeleteComment removes the comment with the given ID with all its replies
+	// and returns the number of the removed comments.
+	DeleteComment(ctx context.Context, id primitive.ObjectID) (int, error)
+
+	// ListComments calls fn for every comment selected by the query
+	// in the creation order until fn returns an error.
+	ListComments(ctx context.Context, q commentQuery, fn func(item *commentItem) error) error
+}
+
+// Store keeps the blogs and their comments.
+type Store interface {
+	BlogStore
+	CommentStore
+
+	// Ping checks that the database is reachable.
+	Ping(ctx context.Context) error
+}
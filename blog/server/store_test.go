@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testStores returns the stores which run without a database server.
+func testStores(t *testing.T) map[string]Store {
+	t.Helper()
+
+	return map[string]Store{
+		"memory": newMemoryStore(),
+		"sqlite": newTestSQLStore(t),
+	}
+}
+
+func TestStoreCRUD(t *testing.T) {
+	ctx := context.Background()
+
+	for name, s := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			first, err := s.Create(ctx, &blogItem{AuthorID: "alice", Title: "first", Content: "c"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			second, err := s.Create(ctx, &blogItem{AuthorID: "bob", Title: "second"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if first.ID.IsZero() || first.ID == second.ID {
+				t.Fatalf("Create() IDs %s and %s, want new ones", first.ID, second.ID)
+			}
+
+			first.Title = "updated"
+			if _, err = s.Update(ctx, first, 0); err != nil {
+				t.Fatal(err)
+			}
+
+			read, err := s.Read(ctx, first.ID)
+			if err != nil || read.Title != "updated" || read.AuthorID != "alice" || read.Content != "c" {
+				t.Errorf("Read() = %+v, %v, want the updated blog", read, err)
+			}
+
+			var listed []string
+
+			err = s.List(ctx, listQuery{}, func(item *blogItem) error {
+				listed = append(listed, item.Title)
+
+				return nil
+			})
+			if err != nil || !reflect.DeepEqual(listed, []string{"updated", "second"}) {
+				t.Errorf("List() = %v, %v, want the blogs in the creation order", listed, err)
+			}
+
+			if _, err = s.Delete(ctx, second.ID, 0); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err = s.Read(ctx, second.ID); !errors.Is(err, errBlogNotFound) {
+				t.Errorf("Read() of a deleted blog error = %v, want errBlogNotFound", err)
+			}
+
+			if _, err = s.Update(ctx, second, 0); !errors.Is(err, errBlogNotFound) {
+				t.Errorf("Update() of a deleted blog error = %v, want errBlogNotFound", err)
+			}
+
+			if _, err = s.Delete(ctx, second.ID, 0); !errors.Is(err, errBlogNotFound) {
+				t.Errorf("Delete() of a deleted blog error = %v, want errBlogNotFound", err)
+			}
+		})
+	}
+}
+
+func TestStoreVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version func(current int64) int64 // the version expected by the write
+		wantErr error
+	}{
+		{"any version", func(int64) int64 { return 0 }, nil},
+		{"current version", func(current int64) int64 { return current }, nil},
+		{"stale version", func(current int64) int64 { return current - 1 }, errVersionMismatch},
+		{"future version", func(current int64) int64 { return current + 1 }, errVersionMismatch},
+	}
+
+	ctx := context.Background()
+
+	for name, s := range testStores(t) {
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				blog, err := s.Create(ctx, &blogItem{AuthorID: "alice", Title: "t"})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				// version 2, so the stale version is not 0
+				if blog, err = s.Update(ctx, blog, 0); err != nil {
+					t.Fatal(err)
+				}
+
+				blog.Title = "updated"
+
+				updated, err := s.Update(ctx, blog, tt.version(blog.Version))
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Update() error = %v, want %v", err, tt.wantErr)
+				}
+
+				if err == nil && (updated.Version != blog.Version+1 || updated.Revision != blog.Revision+1) {
+					t.Errorf("Update() version %d revision %d, want %d %d",
+						updated.Version, updated.Revision, blog.Version+1, blog.Revision+1)
+				}
+
+				current, err := s.Read(ctx, blog.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				_, err = s.Delete(ctx, blog.ID, tt.version(current.Version))
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Delete() error = %v, want %v", err, tt.wantErr)
+				}
+
+				_, err = s.Read(ctx, blog.ID)
+				if deleted := errors.Is(err, errBlogNotFound); deleted != (tt.wantErr == nil) {
+					t.Errorf("Read() after Delete() error = %v", err)
+				}
+			})
+		}
+	}
+}
+
+func TestStoreTimes(t *testing.T) {
+	ctx := context.Background()
+	imported := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for name, s := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			before := storeNow()
+
+			blog, err := s.Create(ctx, &blogItem{AuthorID: "alice", Title: "t"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if blog.CreateTime.Before(before) || !blog.UpdateTime.Equal(blog.CreateTime) {
+				t.Errorf("Create() times %s %s, want now", blog.CreateTime, blog.UpdateTime)
+			}
+
+			if !blog.CreateTime.Equal(blog.CreateTime.Truncate(time.Millisecond)) {
+				t.Errorf("Create() time %s is not in milliseconds", blog.CreateTime)
+			}
+
+			time.Sleep(2 * time.Millisecond)
+
+			updated, err := s.Update(ctx, blog, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !updated.CreateTime.Equal(blog.CreateTime) || !updated.UpdateTime.After(blog.UpdateTime) {
+				t.Errorf("Update() times %s %s, want %s and later", updated.CreateTime, updated.UpdateTime, blog.CreateTime)
+			}
+
+			read, err := s.Read(ctx, blog.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !read.CreateTime.Equal(updated.CreateTime) || !read.UpdateTime.Equal(updated.UpdateTime) {
+				t.Errorf("Read() times %s %s, want %s %s",
+					read.CreateTime, read.UpdateTime, updated.CreateTime, updated.UpdateTime)
+			}
+
+			// an imported blog keeps its times
+			blog, err = s.Create(ctx, &blogItem{AuthorID: "alice", Title: "t", CreateTime: imported})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !blog.CreateTime.Equal(imported) || !blog.UpdateTime.Equal(imported) {
+				t.Errorf("Create() of an imported blog times %s %s, want %s", blog.CreateTime, blog.UpdateTime, imported)
+			}
+		})
+	}
+}
+
+// The stores select and order the blogs as listQuery.apply.
+func TestStoreList(t *testing.T) {
+	blogs := testBlogs()
+	published, draft := true, false
+
+	queries := map[string]listQuery{
+		"all":            {},
+		"descending":     {Descending: true},
+		"by title":       {OrderBy: orderByTitle},
+		"by author":      {OrderBy: orderByAuthor, Descending: true},
+		"by create time": {OrderBy: orderByCreateTime},
+		"by update time": {OrderBy: orderByUpdateTime, Descending: true},
+		"author":         {listFilter: listFilter{AuthorID: "alice"}},
+		"published":      {listFilter: listFilter{Published: &published}},
+		"draft":          {listFilter: listFilter{Published: &draft}},
+		"created after":  {listFilter: listFilter{CreatedAfter: blogs[0].CreateTime}},
+		"created before": {listFilter: listFilter{CreatedBefore: blogs[2].CreateTime}},
+		"updated after":  {listFilter: listFilter{UpdatedAfter: blogs[1].UpdateTime}},
+		"updated before": {listFilter: listFilter{UpdatedBefore: blogs[1].UpdateTime}},
+		"deleted":        {listFilter: listFilter{Deleted: true}},
+		"deleted before": {listFilter: listFilter{Deleted: true, DeletedBefore: blogs[3].DeleteTime.Add(time.Second)}},
+		"any tag":        {listFilter: listFilter{Tags: []string{"go", "grpc"}}},
+		"all tags":       {listFilter: listFilter{Tags: []string{"go", "grpc"}, AllTags: true}},
+		"category":       {listFilter: listFilter{Category: "news"}},
+		"limit":          {OrderBy: orderByTitle, Limit: 2},
+		"after cursor": {
+			OrderBy: orderByCreateTime,
+			After:   &pageCursor{Key: orderByCreateTime.key(blogs[0]), ID: blogs[0].ID.Hex()},
+		},
+	}
+
+	ctx := context.Background()
+
+	for name, s := range testStores(t) {
+		for _, blog := range testBlogs() {
+			if _, err := s.Create(ctx, blog); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		for qname, q := range queries {
+			t.Run(name+"/"+qname, func(t *testing.T) {
+				var got []*blogItem
+
+				err := s.List(ctx, q, func(item *blogItem) error {
+					got = append(got, item)
+
+					return nil
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if want := titles(q.apply(testBlogs())); !reflect.DeepEqual(titles(got), want) {
+					t.Errorf("List() = %v, want %v", titles(got), want)
+				}
+			})
+		}
+	}
+}
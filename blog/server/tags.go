@@ -0,0 +1,80 @@
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// tagCount is the number of the blogs with the tag.
+type tagCount struct {
+	Tag   string `bson:"_id"`
+	Count int64  `bson:"count"`
+}
+
+// normalizeTags lowercases the tags and drops the empty and the repeated ones,
+// the order of the tags is kept.
+func normalizeTags(tags []string) []string {
+	res := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.ToLower(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+
+		seen[tag] = true
+		res = append(res, tag)
+	}
+
+	return res
+}
+
+// hasTags reports whether the blog has any of the tags,
+// or all of them when all is true.
+func hasTags(item *blogItem, tags []string, all bool) bool {
+	for _, tag := range tags {
+		found := false
+
+		for _, t := range item.Tags {
+			if t == tag {
+				found = true
+
+				break
+			}
+		}
+
+		if found != all {
+			return found
+		}
+	}
+
+	return all
+}
+
+// countTags counts the tags of the items, the most used first.
+// Used by the stores which can't aggregate.
+func countTags(items []*blogItem) []tagCount {
+	counts := map[string]int64{}
+
+	for _, item := range items {
+		for _, tag := range item.Tags {
+			counts[tag]++
+		}
+	}
+
+	res := make([]tagCount, 0, len(counts))
+	for tag, n := range counts {
+		res = append(res, tagCount{Tag: tag, Count: n})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Count != res[j].Count {
+			return res[i].Count > res[j].Count
+		}
+
+		return res[i].Tag < res[j].Tag
+	})
+
+	return res
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		tags []string
+		want []string
+	}{
+		{nil, []string{}},
+		{[]string{"Go", "gRPC", "go", "", "GRPC", "news"}, []string{"go", "grpc", "news"}},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeTags(tt.tags); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("normalizeTags(%q) = %q, want %q", tt.tags, got, tt.want)
+		}
+	}
+}
+
+func TestHasTags(t *testing.T) {
+	item := &blogItem{Tags: []string{"go", "grpc"}}
+
+	tests := []struct {
+		tags []string
+		all  bool
+		want bool
+	}{
+		{[]string{"go"}, false, true},
+		{[]string{"news", "grpc"}, false, true},
+		{[]string{"news"}, false, false},
+		{[]string{"grpc", "go"}, true, true},
+		{[]string{"go", "news"}, true, false},
+	}
+
+	for _, tt := range tests {
+		if got := hasTags(item, tt.tags, tt.all); got != tt.want {
+			t.Errorf("hasTags(%q, all %v) = %v, want %v", tt.tags, tt.all, got, tt.want)
+		}
+	}
+}
+
+func TestStoreListTags(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter listFilter
+		want   []tagCount
+	}{
+		// the most used first, then by the tag; the deleted blogs are not counted
+		{"all", listFilter{}, []tagCount{{"go", 2}, {"grpc", 2}, {"old", 1}}},
+		{"author", listFilter{AuthorID: "alice"}, []tagCount{{"go", 1}, {"grpc", 1}}},
+		{"category", listFilter{Category: "news"}, []tagCount{{"grpc", 1}}},
+		{"deleted", listFilter{Deleted: true}, []tagCount{{"old", 1}}},
+		{"none", listFilter{AuthorID: "nobody"}, []tagCount{}},
+	}
+
+	ctx := context.Background()
+
+	for name, s := range testStores(t) {
+		for _, blog := range testBlogs() {
+			if blog.deleted() {
+				blog.Tags = []string{"old"}
+			}
+
+			if _, err := s.Create(ctx, blog); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// a live blog only with a tag of the trash
+		if _, err := s.Create(ctx, &blogItem{AuthorID: "carol", Title: "e", Tags: []string{"old"}}); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				got, err := s.ListTags(ctx, tt.filter)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("ListTags() = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// defaultTrashRetention is how long a deleted blog is kept in the trash.
+	defaultTrashRetention = 30 * 24 * time.Hour
+	// defaultSweepInterval is how often the trash is swept.
+	defaultSweepInterval = time.Hour
+)
+
+// runTrashSweeper purges the blogs deleted more than retention ago
+// every interval until the context is done.
+func runTrashSweeper(ctx context.Context, store BlogStore, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := sweepTrash(ctx, store, time.Now().Add(-retention))
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Cannot sweep the trash: %v", err)
+		}
+
+		if n > 0 {
+			log.Printf("Purged %d deleted blogs", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sweepTrash purges the blogs deleted before the time
+// and returns the number of the purged blogs.
+// The blogs undeleted in the meantime are left.
+func sweepTrash(ctx context.Context, store BlogStore, before time.Time) (int, error) {
+	type trashed struct {
+		id      primitive.ObjectID
+		version int64
+	}
+
+	q := listQuery{
+		listFilter: listFilter{
+			Deleted:       true,
+			DeletedBefore: before,
+		},
+	}
+
+	// collect the blogs first, so the list is not changed while it is read
+	var items []trashed
+
+	err := store.List(ctx, q, func(item *blogItem) error {
+		items = append(items, trashed{item.ID, item.Version})
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+
+	for _, item := range items {
+		// the version changes when the blog is undeleted
+		_, err = store.Delete(ctx, item.id, item.version)
+
+		switch {
+		case err == nil:
+			n++
+		case errors.Is(err, errBlogNotFound), errors.Is(err, errVersionMismatch):
+		default:
+			return n, err
+		}
+	}
+
+	return n, nil
+}
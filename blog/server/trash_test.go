@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStoreSoftDelete(t *testing.T) {
+	ctx := context.Background()
+
+	for name, s := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			blog, err := s.Create(ctx, &blogItem{AuthorID: "alice", Title: "trashed words"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err = s.SoftDelete(ctx, blog.ID, blog.Version+1); !errors.Is(err, errVersionMismatch) {
+				t.Errorf("SoftDelete() of a stale version error = %v, want errVersionMismatch", err)
+			}
+
+			deleted, err := s.SoftDelete(ctx, blog.ID, blog.Version)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if deleted.DeleteTime.IsZero() || deleted.Version != blog.Version+1 {
+				t.Errorf("SoftDelete() = delete time %s version %d, want now and %d",
+					deleted.DeleteTime, deleted.Version, blog.Version+1)
+			}
+
+			// a deleted blog is found only by the queries of the deleted blogs
+			if _, err = s.Read(ctx, blog.ID); !errors.Is(err, errBlogNotFound) {
+				t.Errorf("Read() of a deleted blog error = %v, want errBlogNotFound", err)
+			}
+
+			if _, err = s.Update(ctx, blog, 0); !errors.Is(err, errBlogNotFound) {
+				t.Errorf("Update() of a deleted blog error = %v, want errBlogNotFound", err)
+			}
+
+			if _, err = s.SoftDelete(ctx, blog.ID, 0); !errors.Is(err, errBlogNotFound) {
+				t.Errorf("SoftDelete() of a deleted blog error = %v, want errBlogNotFound", err)
+			}
+
+			var trash []string
+
+			err = s.List(ctx, listQuery{listFilter: listFilter{Deleted: true}}, func(item *blogItem) error {
+				trash = append(trash, item.Title)
+
+				return nil
+			})
+			if err != nil || !reflect.DeepEqual(trash, []string{"trashed words"}) {
+				t.Errorf("List() of the deleted blogs = %v, %v", trash, err)
+			}
+
+			if hits, err := s.Search(ctx, "trashed", 10); err != nil || len(hits) != 0 {
+				t.Errorf("Search() of a deleted blog = %v, %v", hits, err)
+			}
+
+			undeleted, err := s.Undelete(ctx, blog.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !undeleted.DeleteTime.IsZero() || undeleted.Version != deleted.Version+1 {
+				t.Errorf("Undelete() = delete time %s version %d, want none and %d",
+					undeleted.DeleteTime, undeleted.Version, deleted.Version+1)
+			}
+
+			if hits, err := s.Search(ctx, "trashed", 10); err != nil || len(hits) != 1 {
+				t.Errorf("Search() of an undeleted blog = %v, %v", hits, err)
+			}
+
+			// a blog which is not deleted is returned as it is
+			again, err := s.Undelete(ctx, blog.ID)
+			if err != nil || again.Version != undeleted.Version {
+				t.Errorf("Undelete() of a blog = version %d, %v, want %d", again.Version, err, undeleted.Version)
+			}
+		})
+	}
+}
+
+func TestSweepTrash(t *testing.T) {
+	ctx := context.Background()
+
+	for name, s := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			var blogs []*blogItem
+
+			for _, title := range []string{"kept", "purged", "undeleted"} {
+				blog, err := s.Create(ctx, &blogItem{AuthorID: "alice", Title: title})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				blogs = append(blogs, blog)
+			}
+
+			for _, blog := range blogs[1:] {
+				if _, err := s.SoftDelete(ctx, blog.ID, 0); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if _, err := s.Undelete(ctx, blogs[2].ID); err != nil {
+				t.Fatal(err)
+			}
+
+			// the blogs deleted later than the retention are kept
+			if n, err := sweepTrash(ctx, s, time.Now().Add(-time.Hour)); err != nil || n != 0 {
+				t.Errorf("sweepTrash() of an hour ago = %d, %v, want 0", n, err)
+			}
+
+			if n, err := sweepTrash(ctx, s, time.Now().Add(time.Hour)); err != nil || n != 1 {
+				t.Errorf("sweepTrash() = %d, %v, want 1", n, err)
+			}
+
+			if _, err := s.Undelete(ctx, blogs[1].ID); !errors.Is(err, errBlogNotFound) {
+				t.Errorf("Undelete() of a purged blog error = %v, want errBlogNotFound", err)
+			}
+
+			for _, blog := range []*blogItem{blogs[0], blogs[2]} {
+				if _, err := s.Read(ctx, blog.ID); err != nil {
+					t.Errorf("Read() of %s error = %v", blog.Title, err)
+				}
+			}
+		})
+	}
+}
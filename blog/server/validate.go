@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/sergeyzalunin/grpc-go-course/blog/blogpb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+)
+
+// fieldRule is a validation rule of a string field of the blog.
+type fieldRule struct {
+	Value    func(blog *blogpb.Blog) string
+	Required bool
+	MaxLen   int            // max number of characters, 0 means no limit
+	Pattern  *regexp.Regexp // the value must match it when set
+	Allowed  string         // describes the Pattern to the client
+}
+
+// blogRules are the rules of the fields of a blog written by a client,
+// by the field path of the update_mask.
+var blogRules = map[string]fieldRule{
+	"author_id": {
+		Value:    (*blogpb.Blog).GetAuthorId,
+		Required: true,
+		MaxLen:   64,
+		Pattern:  regexp.MustCompile(`^[A-Za-z0-9_.-]*$`),
+		Allowed:  "letters, digits, '_', '.' and '-'",
+	},
+	"title": {
+		Value:    (*blogpb.Blog).GetTitle,
+		Required: true,
+		MaxLen:   200,
+	},
+	"content": {
+		Value:  (*blogpb.Blog).GetContent,
+		MaxLen: 100000,
+	},
+	"category": {
+		Value:  (*blogpb.Blog).GetCategory,
+		MaxLen: 64,
+	},
+}
+
+// tagRule is the rule of every tag of a blog.
+var tagRule = fieldRule{
+	Required: true,
+	MaxLen:   32,
+	Pattern:  regexp.MustCompile(`^[A-Za-z0-9_-]*$`),
+	Allowed:  "letters, digits, '_' and '-'",
+}
+
+// maxTags limits the number of the tags of a blog.
+const maxTags = 20
+
+// blogRuleOrder is the order of the violations of a blog.
+var blogRuleOrder = []string{"author_id", "title", "content", "tags", "category"}
+
+// createdFields are the fields of a created blog set by the client,
+// the author of the blog is the caller.
+var createdFields = blogRuleOrder[1:]
+
+// validateBlog checks the fields listed in paths, all of them when paths is empty,
+// against blogRules. The violations are named after the field of the request.
+func validateBlog(field string, blog *blogpb.Blog, paths []string) []*errdetails.BadRequest_FieldViolation {
+	if blog == nil {
+		return []*errdetails.BadRequest_FieldViolation{{
+			Field:       field,
+			Description: "is required",
+		}}
+	}
+
+	if len(paths) == 0 {
+		paths = blogRuleOrder
+	}
+
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	for _, path := range paths {
+		if path == "tags" {
+			violations = append(violations, validateTags(field+".tags", blog.GetTags())...)
+
+			continue
+		}
+
+		rule, ok := blogRules[path]
+		if !ok {
+			// not a string field, or an unknown one left for checkUpdateMask
+			continue
+		}
+
+		if desc := rule.check(rule.Value(blog)); desc != "" {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       field + "." + path,
+				Description: desc,
+			})
+		}
+	}
+
+	return violations
+}
+
+// validateTags checks the number of the tags and every tag against tagRule.
+func validateTags(field string, tags []string) []*errdetails.BadRequest_FieldViolation {
+	if len(tags) > maxTags {
+		return []*errdetails.BadRequest_FieldViolation{{
+			Field:       field,
+			Description: fmt.Sprintf("must have at most %d tags", maxTags),
+		}}
+	}
+
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	for i, tag := range tags {
+		if desc := tagRule.check(tag); desc != "" {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       fmt.Sprintf("%s[%d]", field, i),
+				Description: desc,
+			})
+		}
+	}
+
+	return violations
+}
+
+// check returns what is wrong with the value, an empty string for a valid one.
+func (r fieldRule) check(value string) string {
+	switch {
+	case r.Required && value == "":
+		return "is required"
+	case r.MaxLen > 0 && utf8.RuneCountInString(value) > r.MaxLen:
+		return fmt.Sprintf("must be at most %d characters long", r.MaxLen)
+	case r.Pattern != nil && !r.Pattern.MatchString(value):
+		return "may contain only " + r.Allowed
+	default:
+		return ""
+	}
+}
+
+// commentContentRule is the rule of the content of a comment.
+var commentContentRule = fieldRule{
+	Required: true,
+	MaxLen:   10000,
+}
+
+// validateComment checks the fields of a comment written by a client,
+// the author of the comment is the caller.
+func validateComment(field string, comment *blogpb.Comment) []*errdetails.BadRequest_FieldViolation {
+	if comment == nil {
+		return []*errdetails.BadRequest_FieldViolation{{
+			Field:       field,
+			Description: "is required",
+		}}
+	}
+
+	if desc := commentContentRule.check(comment.GetContent()); desc != "" {
+		return []*errdetails.BadRequest_FieldViolation{{
+			Field:       field + ".content",
+			Description: desc,
+		}}
+	}
+
+	return nil
+}
+
+// maxBatchSize limits the number of the blogs of a batch request.
+const maxBatchSize = 1000
+
+// validateBatch checks the number of the IDs of a batch request.
+func validateBatch(ids []string) []*errdetails.BadRequest_FieldViolation {
+	if len(ids) <= maxBatchSize {
+		return nil
+	}
+
+	return []*errdetails.BadRequest_FieldViolation{{
+		Field:       "blog_ids",
+		Description: fmt.Sprintf("must have at most %d IDs", maxBatchSize),
+	}}
+}
+
+// validateRequest checks the request of a BlogService or a CommentService method,
+// the requests without the rules are valid.
+func validateRequest(req interface{}) []*errdetails.BadRequest_FieldViolation {
+	switch r := req.(type) {
+	case *blogpb.CreateBlogRequest:
+		return validateBlog("blog", r.GetBlog(), createdFields)
+	case *blogpb.UpdateBlogRequest:
+		return validateBlog("blog", r.GetBlog(), r.GetUpdateMask().GetPaths())
+	case *blogpb.BatchGetBlogsRequest:
+		return validateBatch(r.GetBlogIds())
+	case *blogpb.BatchDeleteBlogsRequest:
+		return validateBatch(r.GetBlogIds())
+	case *blogpb.CreateCommentRequest:
+		return validateComment("comment", r.GetComment())
+	case *blogpb.EditCommentRequest:
+		if desc := commentContentRule.check(r.GetContent()); desc != "" {
+			return []*errdetails.BadRequest_FieldViolation{{
+				Field:       "content",
+				Description: desc,
+			}}
+		}
+
+		return nil
+	default:
+		return nil
+	}
+}
+
+// validationInterceptor rejects the invalid requests with InvalidArgument
+// before they reach the handlers.
+func validationInterceptor(
+	ctx context.Context,
+	req interface{},
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if violations := validateRequest(req); len(violations) > 0 {
+		return nil, fieldViolations(violations)
+	}
+
+	return handler(ctx, req)
+}
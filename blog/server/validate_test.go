@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sergeyzalunin/grpc-go-course/blog/blogpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestFieldRuleCheck(t *testing.T) {
+	author := blogRules["author_id"]
+
+	tests := []struct {
+		name  string
+		rule  fieldRule
+		value string
+		want  string
+	}{
+		{"valid", author, "ninja.dev_1", ""},
+		{"required", author, "", "is required"},
+		{"pattern", author, "ninja turtle", "may contain only letters, digits, '_', '.' and '-'"},
+		{"max length", author, strings.Repeat("n", 65), "must be at most 64 characters long"},
+		// the length is in characters, not bytes
+		{"max length of runes", blogRules["category"], strings.Repeat("ж", 64), ""},
+		{"optional", blogRules["content"], "", ""},
+		{"tag", tagRule, "go.dev", "may contain only letters, digits, '_' and '-'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.check(tt.value); got != tt.want {
+				t.Errorf("check(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateRequest(t *testing.T) {
+	valid := &blogpb.Blog{Title: "t", Tags: []string{"go"}}
+	manyTags := make([]string, maxTags+1)
+
+	for i := range manyTags {
+		manyTags[i] = "go"
+	}
+
+	tests := []struct {
+		name string
+		req  interface{}
+		want []string // the fields of the violations
+	}{
+		{"create", &blogpb.CreateBlogRequest{Blog: valid}, nil},
+		{"create without blog", &blogpb.CreateBlogRequest{}, []string{"blog"}},
+		{
+			"create invalid",
+			&blogpb.CreateBlogRequest{Blog: &blogpb.Blog{Tags: []string{"ok", "not ok"}}},
+			[]string{"blog.title", "blog.tags[1]"},
+		},
+		// the author of a created blog is the caller, so it is not checked
+		{"create with author", &blogpb.CreateBlogRequest{Blog: &blogpb.Blog{AuthorId: "not valid", Title: "t"}}, nil},
+		{"too many tags", &blogpb.CreateBlogRequest{Blog: &blogpb.Blog{Title: "t", Tags: manyTags}}, []string{"blog.tags"}},
+		{
+			"update of all fields",
+			&blogpb.UpdateBlogRequest{Blog: &blogpb.Blog{Title: "t"}},
+			[]string{"blog.author_id"},
+		},
+		{
+			"update of masked fields",
+			&blogpb.UpdateBlogRequest{
+				Blog:       &blogpb.Blog{Content: "c"},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"content"}},
+			},
+			nil,
+		},
+		{"batch", &blogpb.BatchGetBlogsRequest{BlogIds: make([]string, maxBatchSize)}, nil},
+		{"batch too big", &blogpb.BatchDeleteBlogsRequest{BlogIds: make([]string, maxBatchSize+1)}, []string{"blog_ids"}},
+		{"comment", &blogpb.CreateCommentRequest{Comment: &blogpb.Comment{Content: "c"}}, nil},
+		{"empty comment", &blogpb.CreateCommentRequest{Comment: &blogpb.Comment{}}, []string{"comment.content"}},
+		{"empty edit", &blogpb.EditCommentRequest{}, []string{"content"}},
+		{"no rules", &blogpb.ReadBlogRequest{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, v := range validateRequest(tt.req) {
+				got = append(got, v.GetField())
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validateRequest() violations of %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidationInterceptor(t *testing.T) {
+	called := false
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		called = true
+
+		return &blogpb.CreateBlogResponse{}, nil
+	}
+
+	_, err := validationInterceptor(context.Background(), &blogpb.CreateBlogRequest{}, nil, handler)
+	if status.Code(err) != codes.InvalidArgument || called {
+		t.Errorf("invalid request: error = %v, handler called %v", err, called)
+	}
+
+	_, err = validationInterceptor(context.Background(), &blogpb.CreateBlogRequest{Blog: &blogpb.Blog{Title: "t"}}, nil, handler)
+	if err != nil || !called {
+		t.Errorf("valid request: error = %v, handler called %v", err, called)
+	}
+}
@@ -11,15 +11,26 @@ import (
 	"time"
 
 	pb "github.com/sergeyzalunin/grpc-go-course/calculator/calculatorpb"
-	"google.golang.org/grpc"
+	"github.com/sergeyzalunin/grpc-go-course/clientkit"
+	"github.com/sergeyzalunin/grpc-go-course/config"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// envPrefix is the prefix of the environment variables of the client config.
+const envPrefix = "CALCULATOR_"
+
 func main() {
+	// the calculator runs without TLS by default
+	cfg := clientkit.DefaultConfig("localhost:50051")
+
+	if err := config.Load(&cfg, envPrefix); err != nil {
+		log.Fatal(err)
+	}
+
 	fmt.Println("Hi from client")
 
-	cc, err := grpc.Dial("localhost:50051", grpc.WithInsecure())
+	cc, err := clientkit.Dial(cfg)
 	if err != nil {
 		log.Fatal(err)
 	}
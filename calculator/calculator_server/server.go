@@ -6,29 +6,56 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"net"
 
 	pb "github.com/sergeyzalunin/grpc-go-course/calculator/calculatorpb"
-	"google.golang.org/grpc"
+	"github.com/sergeyzalunin/grpc-go-course/config"
+	"github.com/sergeyzalunin/grpc-go-course/serverkit"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 )
 
+// envPrefix is the prefix of the environment variables of the server config.
+const envPrefix = "CALCULATOR_SERVER_"
+
+// serverConfig is the config of the server loaded by the config package.
+type serverConfig struct {
+	Server   serverkit.Config `yaml:",inline"`
+	AuthKeys string           `yaml:"auth_keys" help:"directory of the AuthService token keys, no authentication when empty"`
+}
+
+func (c *serverConfig) Validate(check *config.Check) {
+	c.Server.Validate(check)
+	check.File("auth_keys", c.AuthKeys)
+}
+
 func main() {
+	cfg := &serverConfig{Server: serverkit.DefaultConfig("calculator", "0.0.0.0:50051")}
+	if err := config.Load(cfg, envPrefix); err != nil {
+		log.Fatal(err)
+	}
+
 	fmt.Println("Hi")
 
-	listener, err := net.Listen("tcp", "0.0.0.0:50051") //nolint
+	// the keys are watched while the server runs
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	interceptors, err := serverkit.AuthInterceptors(ctx, cfg.Server, cfg.AuthKeys)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cfg.Server.Interceptors = interceptors
+
+	s, err := serverkit.New(cfg.Server)
 	if err != nil {
-		fmt.Println(err)
+		log.Fatal(err)
 	}
 
-	s := grpc.NewServer()
-	pb.RegisterCalculatorServiceServer(s, &server{})
-	reflection.Register(s)
+	pb.RegisterCalculatorServiceServer(s.GRPC, &server{})
 
-	if err = s.Serve(listener); err != nil {
-		fmt.Print(err)
+	if err = s.Run(ctx); err != nil {
+		log.Fatal(err)
 	}
 }
 
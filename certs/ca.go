@@ -0,0 +1,297 @@
+package certs
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// the files of a CA directory, named as by minica,
+// so the directories made by either of them are interchangeable
+const (
+	CACertFile = "minica.pem"
+	CAKeyFile  = "minica-key.pem"
+	CertFile   = "cert.pem"
+	KeyFile    = "key.pem"
+)
+
+// backdate is how long before now the certificates are valid
+// for the clocks of the hosts not quite in sync.
+const backdate = 5 * time.Minute
+
+// CA is the development certificate authority issuing
+// the certificates of the servers and the clients.
+type CA struct {
+	Cert *x509.Certificate
+	key  crypto.Signer
+}
+
+// Leaf describes an issued certificate.
+type Leaf struct {
+	Names    []string      // the DNS names and IP addresses, the first one is the common name
+	Roles    []string      // the organizational units, the roles of a client
+	Client   bool          // for the client authentication instead of the server one
+	Validity time.Duration // how long it is valid
+}
+
+// NewCA makes a CA with a new key valid for validity.
+func NewCA(validity time.Duration) (*CA, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	serial, err := serialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "grpc-go-course dev CA " + serial.Text(16)[:6]},
+		NotBefore:             now.Add(-backdate),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CA{Cert: cert, key: key}, nil
+}
+
+// LoadCA reads the CA of the directory.
+func LoadCA(dir string) (*CA, error) {
+	pair, err := tls.LoadX509KeyPair(filepath.Join(dir, CACertFile), filepath.Join(dir, CAKeyFile))
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if !cert.IsCA {
+		return nil, fmt.Errorf("%s is not a CA certificate", filepath.Join(dir, CACertFile))
+	}
+
+	key, ok := pair.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("CA key cannot sign")
+	}
+
+	return &CA{Cert: cert, key: key}, nil
+}
+
+// Save writes the certificate and the key of the CA to the directory.
+func (ca *CA) Save(dir string) error {
+	return writePair(dir, CACertFile, CAKeyFile, ca.Cert.Raw, ca.key)
+}
+
+// Issue returns the certificate signed by the CA and its key, both PEM encoded.
+func (ca *CA) Issue(leaf Leaf) (certPEM, keyPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template, err := leafTemplate(leaf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if template.NotAfter.After(ca.Cert.NotAfter) {
+		return nil, nil, fmt.Errorf("certificate would outlive the CA expiring on %s",
+			ca.Cert.NotAfter.Format(time.RFC3339))
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.Cert, key.Public(), ca.key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyPEM, err = encodeKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return encodeCert(der), keyPEM, nil
+}
+
+// IssueTo writes the certificate signed by the CA and its key
+// to cert.pem and key.pem of the directory.
+func (ca *CA) IssueTo(dir string, leaf Leaf) error {
+	certPEM, keyPEM, err := ca.Issue(leaf)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	// the key is written first, so a server reloading the pair
+	// finds the new key by the time the new certificate shows up
+	if err = writeFile(filepath.Join(dir, KeyFile), keyPEM, 0o600); err != nil {
+		return err
+	}
+
+	return writeFile(filepath.Join(dir, CertFile), certPEM, 0o644)
+}
+
+// SelfSigned returns an ephemeral self-signed certificate of a server for the names
+// and the certificate PEM encoded for its clients to trust.
+func SelfSigned(names []string, validity time.Duration) (tls.Certificate, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+
+	template, err := leafTemplate(Leaf{Names: names, Validity: validity})
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+
+	cert := tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}
+
+	return cert, encodeCert(der), nil
+}
+
+func leafTemplate(leaf Leaf) (*x509.Certificate, error) {
+	if len(leaf.Names) == 0 {
+		return nil, errors.New("certificate needs a DNS name or an IP address")
+	}
+
+	serial, err := serialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:         leaf.Names[0],
+			OrganizationalUnit: leaf.Roles,
+		},
+		NotBefore:             now.Add(-backdate),
+		NotAfter:              now.Add(leaf.Validity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+
+	if leaf.Client {
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	}
+
+	for _, name := range leaf.Names {
+		if ip := net.ParseIP(name); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, name)
+		}
+	}
+
+	return template, nil
+}
+
+func serialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+func encodeCert(der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func encodeKey(key crypto.Signer) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+func writePair(dir, certName, keyName string, der []byte, key crypto.Signer) error {
+	keyPEM, err := encodeKey(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	if err = writeFile(filepath.Join(dir, keyName), keyPEM, 0o600); err != nil {
+		return err
+	}
+
+	return writeFile(filepath.Join(dir, certName), encodeCert(der), 0o644)
+}
+
+// writeFile replaces the file at once by renaming a temporary file,
+// so the servers watching it never read it half written.
+func writeFile(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+
+		return err
+	}
+
+	if err = tmp.Chmod(perm); err != nil {
+		tmp.Close()
+
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
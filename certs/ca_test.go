@@ -0,0 +1,183 @@
+package certs
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// parseCert returns the first certificate of the PEM data.
+func parseCert(t *testing.T, data []byte) *x509.Certificate {
+	t.Helper()
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		t.Fatal("no PEM block")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert
+}
+
+func TestIssue(t *testing.T) {
+	ca, err := NewCA(24 * time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Cert)
+
+	tests := []struct {
+		name    string
+		leaf    Leaf
+		usage   x509.ExtKeyUsage
+		dns     []string
+		ips     int
+		wantErr bool
+	}{
+		{
+			"server",
+			Leaf{Names: []string{"localhost", "127.0.0.1", "::1"}, Validity: time.Hour},
+			x509.ExtKeyUsageServerAuth,
+			[]string{"localhost"},
+			2,
+			false,
+		},
+		{
+			"client",
+			Leaf{Names: []string{"ninja"}, Roles: []string{"admin"}, Client: true, Validity: time.Hour},
+			x509.ExtKeyUsageClientAuth,
+			[]string{"ninja"},
+			0,
+			false,
+		},
+		{"no names", Leaf{Validity: time.Hour}, 0, nil, 0, true},
+		{"outlives the CA", Leaf{Names: []string{"localhost"}, Validity: 48 * time.Hour}, 0, nil, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			certPEM, keyPEM, err := ca.Issue(tt.leaf)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Issue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			cert := parseCert(t, certPEM)
+
+			_, err = cert.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{tt.usage}})
+			if err != nil {
+				t.Errorf("Verify() error = %v", err)
+			}
+
+			if cert.Subject.CommonName != tt.leaf.Names[0] ||
+				!reflect.DeepEqual(cert.DNSNames, tt.dns) || len(cert.IPAddresses) != tt.ips {
+				t.Errorf("certificate of %s, DNS %v, IPs %v", cert.Subject.CommonName, cert.DNSNames, cert.IPAddresses)
+			}
+
+			if !reflect.DeepEqual(cert.Subject.OrganizationalUnit, tt.leaf.Roles) {
+				t.Errorf("roles %v, want %v", cert.Subject.OrganizationalUnit, tt.leaf.Roles)
+			}
+
+			if block, _ := pem.Decode(keyPEM); block == nil || block.Type != "PRIVATE KEY" {
+				t.Errorf("key is not a PEM private key")
+			}
+		})
+	}
+}
+
+func TestLoadCA(t *testing.T) {
+	ca, err := NewCA(24 * time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	if err = ca.Save(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, CAKeyFile))
+	if err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("CA key %v, %v, want mode 0600", info, err)
+	}
+
+	loaded, err := LoadCA(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !loaded.Cert.Equal(ca.Cert) {
+		t.Errorf("LoadCA() = %s, want %s", loaded.Cert.Subject, ca.Cert.Subject)
+	}
+
+	// the loaded CA issues the certificates trusted by the saved one
+	leafDir := filepath.Join(dir, "localhost")
+	if err = loaded.IssueTo(leafDir, Leaf{Names: []string{"localhost"}, Validity: time.Hour}); err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM, err := os.ReadFile(filepath.Join(leafDir, CertFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Cert)
+
+	if _, err = parseCert(t, certPEM).Verify(x509.VerifyOptions{Roots: roots}); err != nil {
+		t.Errorf("Verify() of a certificate of the loaded CA error = %v", err)
+	}
+
+	// a server certificate is not a CA
+	if err = os.Rename(filepath.Join(leafDir, CertFile), filepath.Join(leafDir, CACertFile)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = os.Rename(filepath.Join(leafDir, KeyFile), filepath.Join(leafDir, CAKeyFile)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = LoadCA(leafDir); err == nil {
+		t.Error("LoadCA() of a server certificate succeeded")
+	}
+
+	if _, err = LoadCA(t.TempDir()); err == nil {
+		t.Error("LoadCA() of an empty directory succeeded")
+	}
+}
+
+func TestSelfSigned(t *testing.T) {
+	cert, certPEM, err := SelfSigned([]string{"localhost", "127.0.0.1"}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(certPEM) {
+		t.Fatal("the PEM of the certificate has no certificate")
+	}
+
+	// the clients trusting the PEM accept the certificate for both names
+	for _, name := range []string{"localhost", "127.0.0.1"} {
+		if _, err = cert.Leaf.Verify(x509.VerifyOptions{Roots: roots, DNSName: name}); err != nil {
+			t.Errorf("Verify() of %s error = %v", name, err)
+		}
+	}
+
+	if _, _, err = SelfSigned(nil, time.Hour); err == nil {
+		t.Error("SelfSigned() without names succeeded")
+	}
+}
@@ -0,0 +1,184 @@
+// Package certs keeps the TLS certificates of the servers up to date
+// with their files, so a rotated certificate is used without a restart,
+// and issues the development certificates.
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCheckInterval is how often the files are checked for changes.
+	DefaultCheckInterval = 10 * time.Second
+	// DefaultWarnBefore is how long before the expiry the warnings are logged.
+	DefaultWarnBefore = 30 * 24 * time.Hour
+	// warnEvery limits the expiry warnings of a certificate.
+	warnEvery = 24 * time.Hour
+)
+
+// fileState identifies a version of a file.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// Manager is the certificate of a server loaded from its certificate and key files.
+// Its GetCertificate returns the latest loaded certificate for every new handshake,
+// the established connections keep theirs.
+type Manager struct {
+	certFile, keyFile string
+	warnBefore        time.Duration
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	states   [2]fileState // of the certificate and the key files
+	lastWarn time.Time
+}
+
+// NewManager loads the certificate of the files.
+// The expiry warnings are logged when it expires in less than warnBefore.
+func NewManager(certFile, keyFile string, warnBefore time.Duration) (*Manager, error) {
+	m := &Manager{
+		certFile:   certFile,
+		keyFile:    keyFile,
+		warnBefore: warnBefore,
+	}
+
+	if err := m.Reload(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// GetCertificate returns the current certificate, it is set to tls.Config.GetCertificate.
+func (m *Manager) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.cert, nil
+}
+
+// Reload loads the certificate of the files,
+// the current one is kept when they can't be loaded.
+func (m *Manager) Reload() error {
+	states, err := m.fileStates()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(m.certFile, m.keyFile)
+	if err != nil {
+		return err
+	}
+
+	if cert.Leaf == nil {
+		if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+			return err
+		}
+	}
+
+	if time.Now().After(cert.Leaf.NotAfter) {
+		return fmt.Errorf("certificate %s expired on %s", m.certFile, cert.Leaf.NotAfter.Format(time.RFC3339))
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.cert = &cert
+	m.states = states
+	m.lastWarn = time.Time{}
+
+	return nil
+}
+
+// Watch checks the files every interval until the context is done,
+// reloads the certificate when they change and warns about its expiry.
+// The files are polled instead of watched for the events,
+// as a rotator may remove the whole directory and make it again.
+func (m *Manager) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	m.warnExpiry()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if m.changed() {
+			// the cert and the key may be written one by one,
+			// so a failed reload is retried on the next check
+			if err := m.Reload(); err != nil {
+				log.Printf("Cannot reload the certificate: %v", err)
+			} else {
+				log.Printf("Reloaded the certificate %s", m.certFile)
+			}
+		}
+
+		m.warnExpiry()
+	}
+}
+
+// changed reports whether the files changed since the certificate was loaded.
+func (m *Manager) changed() bool {
+	states, err := m.fileStates()
+	if err != nil {
+		// the files are being rewritten, check them again later
+		return false
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return states != m.states
+}
+
+func (m *Manager) fileStates() ([2]fileState, error) {
+	var states [2]fileState
+
+	for i, file := range []string{m.certFile, m.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return states, err
+		}
+
+		states[i] = fileState{info.ModTime(), info.Size()}
+	}
+
+	return states, nil
+}
+
+// warnExpiry logs a warning once a day when the certificate expires soon.
+func (m *Manager) warnExpiry() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	notAfter := m.cert.Leaf.NotAfter
+
+	if notAfter.Sub(now) > m.warnBefore || now.Sub(m.lastWarn) < warnEvery {
+		return
+	}
+
+	m.lastWarn = now
+
+	if now.After(notAfter) {
+		log.Printf("Certificate %s expired on %s, renew it", m.certFile, notAfter.Format(time.RFC3339))
+
+		return
+	}
+
+	log.Printf("Certificate %s expires on %s, in %s, renew it",
+		m.certFile, notAfter.Format(time.RFC3339), notAfter.Sub(now).Round(time.Hour))
+}
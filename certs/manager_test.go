@@ -0,0 +1,115 @@
+package certs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// issue writes a server certificate of the name to the directory.
+func issue(t *testing.T, ca *CA, dir, name string, validity time.Duration) {
+	t.Helper()
+
+	if err := ca.IssueTo(dir, Leaf{Names: []string{name}, Validity: validity}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// commonName returns the common name of the current certificate of the manager.
+func commonName(t *testing.T, m *Manager) string {
+	t.Helper()
+
+	cert, err := m.GetCertificate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert.Leaf.Subject.CommonName
+}
+
+func TestManagerWatch(t *testing.T) {
+	ca, err := NewCA(24 * time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	issue(t, ca, dir, "first.example.com", time.Hour)
+
+	m, err := NewManager(filepath.Join(dir, CertFile), filepath.Join(dir, KeyFile), DefaultWarnBefore)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if name := commonName(t, m); name != "first.example.com" {
+		t.Fatalf("certificate of %s, want first.example.com", name)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go m.Watch(ctx, 10*time.Millisecond)
+
+	issue(t, ca, dir, "rotated.example.com", time.Hour)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for commonName(t, m) != "rotated.example.com" {
+		if time.Now().After(deadline) {
+			t.Fatal("the rotated certificate was not loaded")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestManagerReloadKeepsCertificate(t *testing.T) {
+	ca, err := NewCA(24 * time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	issue(t, ca, dir, "first.example.com", time.Hour)
+
+	certFile, keyFile := filepath.Join(dir, CertFile), filepath.Join(dir, KeyFile)
+
+	m, err := NewManager(certFile, keyFile, DefaultWarnBefore)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		rewrite func(t *testing.T)
+	}{
+		{"half written", func(t *testing.T) {
+			if err := os.WriteFile(certFile, []byte("-----BEGIN CERTIFICATE-----\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}},
+		{"removed", func(t *testing.T) {
+			if err := os.Remove(certFile); err != nil {
+				t.Fatal(err)
+			}
+		}},
+		{"expired", func(t *testing.T) {
+			issue(t, ca, dir, "expired.example.com", -time.Minute)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.rewrite(t)
+
+			if err := m.Reload(); err == nil {
+				t.Error("Reload() returned no error")
+			}
+
+			if name := commonName(t, m); name != "first.example.com" {
+				t.Errorf("certificate of %s, want the loaded one", name)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Package clientkit dials the gRPC servers of the services from the client configs:
+// the TLS trusting the CA of the servers, the client certificate, the bearer token
+// and the metrics of the calls.
+package clientkit
+
+import (
+	"github.com/sergeyzalunin/grpc-go-course/auth"
+	"github.com/sergeyzalunin/grpc-go-course/config"
+	"github.com/sergeyzalunin/grpc-go-course/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Config configures a client, the fields with the yaml tags are loaded by the config package.
+type Config struct {
+	Addr  string        `yaml:"addr" help:"address of the server"`
+	TLS   TLS           `yaml:"tls"`
+	Token config.Secret `yaml:"token" help:"bearer token of AuthService or of the tokens file of the server"`
+
+	// the address of the HTTP /metrics of the calls, not served and not recorded when empty
+	MetricsAddr string `yaml:"metrics_addr" help:"address of the HTTP /metrics of the calls, off when empty"`
+}
+
+// DefaultConfig returns the config of the client of the server on the address
+// without TLS, the CA of the server certificate is the one of gen_cert.sh.
+func DefaultConfig(addr string) Config {
+	return Config{
+		Addr: addr,
+		TLS: TLS{
+			CAFile: DefaultCAFile,
+		},
+	}
+}
+
+// Validate checks the loaded config.
+func (c *Config) Validate(check *config.Check) {
+	check.Addr("addr", c.Addr)
+	c.TLS.Validate(check)
+
+	if c.MetricsAddr != "" {
+		check.Addr("metrics_addr", c.MetricsAddr)
+	}
+}
+
+// Dial connects to the server of the config, the options are added to those of the config.
+func Dial(c Config, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	creds, err := c.TLS.DialOption()
+	if err != nil {
+		return nil, err
+	}
+
+	metricsOpts, err := metrics.ServeClient(c.MetricsAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(append([]grpc.DialOption{creds}, metricsOpts...), opts...)
+
+	// the servers started with the auth keys or the tokens file need a token
+	if token := tokenCredentials(c.Token.Value(), c.TLS.Enabled); token != nil {
+		opts = append(opts, grpc.WithPerRPCCredentials(token))
+	}
+
+	return grpc.Dial(c.Addr, opts...)
+}
+
+// tokenCredentials returns the credentials sending the token, none when it is empty.
+// The token goes without TLS only when TLS is off, for the local development.
+func tokenCredentials(token string, tlsEnabled bool) credentials.PerRPCCredentials {
+	if token == "" {
+		return nil
+	}
+
+	return auth.TokenCredentials{
+		Token:         token,
+		AllowInsecure: !tlsEnabled,
+	}
+}
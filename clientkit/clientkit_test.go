@@ -0,0 +1,154 @@
+package clientkit
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sergeyzalunin/grpc-go-course/certs"
+	"github.com/sergeyzalunin/grpc-go-course/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// writeCA writes the certificate of a new CA and a client certificate signed by it
+// to the directory and returns the TLS config of a client using them.
+func writeCA(t *testing.T, dir string) TLS {
+	t.Helper()
+
+	ca, err := certs.NewCA(time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = ca.Save(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	clientDir := filepath.Join(dir, "client")
+	if err = ca.IssueTo(clientDir, certs.Leaf{Names: []string{"alice"}, Client: true, Validity: time.Minute}); err != nil {
+		t.Fatal(err)
+	}
+
+	return TLS{
+		Enabled:  true,
+		CAFile:   filepath.Join(dir, certs.CACertFile),
+		CertFile: filepath.Join(clientDir, certs.CertFile),
+		KeyFile:  filepath.Join(clientDir, certs.KeyFile),
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	valid := writeCA(t, t.TempDir())
+
+	tests := []struct {
+		name    string
+		change  func(c *Config)
+		wantErr bool
+	}{
+		{"default", func(*Config) {}, false},
+		{"TLS", func(c *Config) { c.TLS = valid }, false},
+		{"bad addr", func(c *Config) { c.Addr = "localhost" }, true},
+		{"bad metrics addr", func(c *Config) { c.MetricsAddr = "localhost" }, true},
+		{"missing CA", func(c *Config) { c.TLS = TLS{Enabled: true, CAFile: filepath.Join(t.TempDir(), "ca.pem")} }, true},
+		{"cert without key", func(c *Config) { c.TLS.CertFile = valid.CertFile }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := DefaultConfig("localhost:50051")
+			tt.change(&c)
+
+			check := &config.Check{}
+			c.Validate(check)
+
+			if err := check.Err(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTLSDialOption(t *testing.T) {
+	dir := t.TempDir()
+	valid := writeCA(t, dir)
+
+	notCA := filepath.Join(dir, "not-ca.pem")
+	if err := os.WriteFile(notCA, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		tls     TLS
+		wantErr bool
+	}{
+		{"off", TLS{}, false},
+		{"CA", TLS{Enabled: true, CAFile: valid.CAFile}, false},
+		{"client certificate", valid, false},
+		{"missing CA", TLS{Enabled: true, CAFile: filepath.Join(dir, "missing.pem")}, true},
+		{"no certificates in CA", TLS{Enabled: true, CAFile: notCA}, true},
+		{"missing client certificate", TLS{Enabled: true, CAFile: valid.CAFile, CertFile: notCA, KeyFile: valid.KeyFile}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opt, err := tt.tls.DialOption()
+			if (err != nil) != tt.wantErr || (err == nil) != (opt != nil) {
+				t.Errorf("DialOption() = %v, %v, wantErr %v", opt, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTokenCredentials(t *testing.T) {
+	if creds := tokenCredentials("", false); creds != nil {
+		t.Errorf("tokenCredentials() of no token = %v, want none", creds)
+	}
+
+	// the token goes without TLS only to the servers the client reaches without TLS
+	for _, tlsEnabled := range []bool{false, true} {
+		creds := tokenCredentials("s3cr3t", tlsEnabled)
+		if creds == nil || creds.RequireTransportSecurity() != tlsEnabled {
+			t.Errorf("TLS %v: tokenCredentials() = %v, want TLS required %v", tlsEnabled, creds, tlsEnabled)
+		}
+	}
+}
+
+func TestDial(t *testing.T) {
+	s := grpc.NewServer()
+	healthpb.RegisterHealthServer(s, health.NewServer())
+
+	cc, err := Dial(DefaultConfig(serve(t, s)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cc.Close()
+
+	res, err := healthpb.NewHealthClient(cc).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil || res.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("Check() = %v, %v, want SERVING", res, err)
+	}
+}
+
+// serve serves the server on a local port until the test ends and returns its address.
+func serve(t *testing.T, s *grpc.Server) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		_ = s.Serve(listener)
+	}()
+
+	t.Cleanup(s.Stop)
+
+	return listener.Addr().String()
+}
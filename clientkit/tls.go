@@ -0,0 +1,74 @@
+package clientkit
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/sergeyzalunin/grpc-go-course/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// DefaultCAFile is the CA made by gen_cert.sh, relative to the directory of a client.
+const DefaultCAFile = "../../ssl/minica.pem"
+
+// TLS configures the TLS of a client.
+type TLS struct {
+	Enabled bool   `yaml:"enabled" flag:"tls" help:"connect with TLS"`
+	CAFile  string `yaml:"ca_file" flag:"ca" env:"SERVER_CA" help:"CA certificate of the server certificate"`
+
+	// the certificate presented to the servers requiring the client certificates
+	CertFile string `yaml:"cert_file" flag:"cert" env:"CLIENT_CERT" help:"client certificate identifying the caller"`
+	KeyFile  string `yaml:"key_file" flag:"key" env:"CLIENT_KEY" help:"key of the client certificate"`
+}
+
+// Validate checks the loaded TLS config of the tls key.
+func (t *TLS) Validate(check *config.Check) {
+	if t.Enabled {
+		check.File("tls.ca_file", t.CAFile)
+		check.File("tls.cert_file", t.CertFile)
+		check.File("tls.key_file", t.KeyFile)
+	}
+
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		check.Errorf("tls", "cert_file and key_file are set together")
+	}
+}
+
+// DialOption returns the transport credentials of the connection,
+// trusting the server certificates signed by the CA of CAFile,
+// or an insecure connection when TLS is off.
+// To use the certificates of gen_cert.sh, start it manually first.
+func (t *TLS) DialOption() (grpc.DialOption, error) {
+	if !t.Enabled {
+		return grpc.WithTransportCredentials(insecure.NewCredentials()), nil
+	}
+
+	pem, err := os.ReadFile(t.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading server CA: %w", err)
+	}
+
+	config := &tls.Config{
+		RootCAs:    x509.NewCertPool(),
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if !config.RootCAs.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates in server CA %s", t.CAFile)
+	}
+
+	if t.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return grpc.WithTransportCredentials(credentials.NewTLS(config)), nil
+}
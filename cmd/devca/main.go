@@ -0,0 +1,89 @@
+// Command devca is the development CA issuing the certificates of the servers and the clients,
+// it writes them in the layout of minica:
+//
+//	ssl/minica.pem, ssl/minica-key.pem      the CA, made on the first run
+//	ssl/localhost/cert.pem, key.pem         the certificate of the first name
+//
+// Run from the repository root:
+//
+//	go run ./cmd/devca -domains localhost -ip-addresses 127.0.0.1,::1
+//	go run ./cmd/devca -client -domains ninja -roles admin
+//
+// An issued certificate is replaced by a new one on every run,
+// the running servers reload it.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sergeyzalunin/grpc-go-course/certs"
+)
+
+func main() {
+	dir := flag.String("dir", "ssl", "directory of the CA and the issued certificates")
+	domains := flag.String("domains", "", "comma separated DNS names of the certificate")
+	ips := flag.String("ip-addresses", "", "comma separated IP addresses of the certificate")
+	client := flag.Bool("client", false, "issue a client certificate, its first name is the caller")
+	roles := flag.String("roles", "", "comma separated roles of the client, its organizational units")
+	validity := flag.Duration("validity", 365*24*time.Hour, "how long the issued certificate is valid")
+	caValidity := flag.Duration("ca-validity", 10*365*24*time.Hour, "how long a new CA is valid")
+	flag.Parse()
+
+	log.SetFlags(0)
+
+	names := append(split(*domains), split(*ips)...)
+	if len(names) == 0 {
+		log.Fatal("Set -domains or -ip-addresses")
+	}
+
+	ca, err := certs.LoadCA(*dir)
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		if ca, err = certs.NewCA(*caValidity); err != nil {
+			log.Fatalf("Cannot create the CA: %v", err)
+		}
+
+		if err = ca.Save(*dir); err != nil {
+			log.Fatalf("Cannot save the CA: %v", err)
+		}
+
+		fmt.Printf("Created CA %s in %s\n", ca.Cert.Subject.CommonName, filepath.Join(*dir, certs.CACertFile))
+	case err != nil:
+		log.Fatalf("Cannot load the CA: %v", err)
+	}
+
+	leaf := certs.Leaf{
+		Names:    names,
+		Roles:    split(*roles),
+		Client:   *client,
+		Validity: *validity,
+	}
+
+	// the directory is named after the first name as by minica, with the colons of IPv6 replaced
+	leafDir := filepath.Join(*dir, strings.ReplaceAll(names[0], ":", "_"))
+	if err = ca.IssueTo(leafDir, leaf); err != nil {
+		log.Fatalf("Cannot issue the certificate: %v", err)
+	}
+
+	fmt.Printf("Issued certificate for %s in %s\n", strings.Join(names, ", "), leafDir)
+}
+
+func split(list string) []string {
+	var items []string
+
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
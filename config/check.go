@@ -0,0 +1,57 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+)
+
+// Check collects the problems of a config.
+type Check struct {
+	problems []string
+}
+
+// Errorf adds a problem of the key.
+func (c *Check) Errorf(key, format string, args ...interface{}) {
+	c.problems = append(c.problems, key+": "+fmt.Sprintf(format, args...))
+}
+
+// Addr checks the address is a host and a port.
+func (c *Check) Addr(key, addr string) {
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		c.Errorf(key, "%v", err)
+	}
+}
+
+// File checks the file exists when it is set.
+func (c *Check) File(key, path string) {
+	if path == "" {
+		return
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		c.Errorf(key, "%v", err)
+	}
+}
+
+// OneOf checks the value is one of the values.
+func (c *Check) OneOf(key, value string, values ...string) {
+	for _, v := range values {
+		if v == value {
+			return
+		}
+	}
+
+	c.Errorf(key, "%q is not one of %s", value, strings.Join(values, ", "))
+}
+
+// Err returns the problems, nil when there are none.
+func (c *Check) Err() error {
+	if len(c.problems) == 0 {
+		return nil
+	}
+
+	return errors.New("invalid config:\n  " + strings.Join(c.problems, "\n  "))
+}
@@ -0,0 +1,336 @@
+// Package config loads the configs of the servers and the clients in layers:
+// the defaults set by the binary, then the YAML config file, then the environment, then the flags.
+//
+// A config is a struct, its fields with a yaml tag are loaded:
+//
+//	type config struct {
+//		Addr string `yaml:"addr" help:"address to listen on"`
+//		TLS  struct {
+//			ClientCA string `yaml:"client_ca" flag:"client-ca" help:"CA of the client certificates"`
+//		} `yaml:"tls"`
+//	}
+//
+// The key tls.client_ca is set by the YAML
+//
+//	tls:
+//	  client_ca: ca.pem
+//
+// by the environment variable of the prefix and the key, PREFIX_TLS_CLIENT_CA,
+// and by the flag -tls-client-ca, the env and flag tags rename them.
+// The fields are strings, bools, ints, durations, comma separated string lists,
+// structs of more fields and Secrets.
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Validator is a config checking its values once they are loaded.
+type Validator interface {
+	Validate(check *Check)
+}
+
+// field is a loaded field of a config.
+type field struct {
+	key   string // the YAML path: tls.client_ca
+	env   string // the environment variable: PREFIX_TLS_CLIENT_CA
+	flag  string // the flag: tls-client-ca
+	help  string
+	value reflect.Value
+}
+
+func (f *field) secret() *Secret {
+	s, _ := f.value.Addr().Interface().(*Secret)
+
+	return s
+}
+
+// assignment is a value of a field set by a layer.
+type assignment struct {
+	field  *field
+	value  string
+	file   bool   // the value is the file of a secret
+	source string // where the value is set, for the errors
+}
+
+func (a *assignment) apply() error {
+	if s := a.field.secret(); s != nil {
+		if a.file {
+			s.value, s.file = "", a.value
+		} else {
+			s.value, s.file = a.value, ""
+		}
+
+		return nil
+	}
+
+	if err := set(a.field.value, a.value); err != nil {
+		return fmt.Errorf("%s: %s: %w", a.source, a.field.key, err)
+	}
+
+	return nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// set parses the value to the field.
+func set(v reflect.Value, value string) error {
+	switch {
+	case v.Type() == durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(int64(d))
+	case v.Kind() == reflect.String:
+		v.SetString(value)
+	case v.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+
+		v.SetBool(b)
+	case v.Kind() == reflect.Int:
+		i, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(int64(i))
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+		v.Set(reflect.ValueOf(splitList(value)))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
+
+func splitList(list string) []string {
+	items := []string{}
+
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// fields returns the loaded fields of the struct.
+func fields(v reflect.Value, keyPrefix, envPrefix string) []*field {
+	var fs []*field
+
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+
+		name, ok := sf.Tag.Lookup("yaml")
+		if !ok || name == "-" || sf.PkgPath != "" {
+			continue
+		}
+
+		if name == ",inline" {
+			fs = append(fs, fields(v.Field(i), keyPrefix, envPrefix)...)
+
+			continue
+		}
+
+		key := keyPrefix + name
+
+		if v.Field(i).Kind() == reflect.Struct && sf.Type != secretType && sf.Type != durationType {
+			fs = append(fs, fields(v.Field(i), key+".", envPrefix)...)
+
+			continue
+		}
+
+		f := &field{
+			key:   key,
+			env:   sf.Tag.Get("env"),
+			flag:  sf.Tag.Get("flag"),
+			help:  sf.Tag.Get("help"),
+			value: v.Field(i),
+		}
+
+		if f.env == "" {
+			f.env = envPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+		}
+
+		if f.flag == "" {
+			f.flag = strings.NewReplacer(".", "-", "_", "-").Replace(key)
+		}
+
+		fs = append(fs, f)
+	}
+
+	return fs
+}
+
+// flagValue records a flag, applied over the config file and the environment.
+type flagValue struct {
+	field  *field
+	file   bool
+	def    string
+	values *[]assignment
+}
+
+func (v *flagValue) String() string {
+	return v.def
+}
+
+func (v *flagValue) Set(value string) error {
+	if !v.file && v.field.secret() == nil {
+		// checked at once, so the flag package reports it with the usage
+		if err := set(reflect.New(v.field.value.Type()).Elem(), value); err != nil {
+			return err
+		}
+	}
+
+	*v.values = append(*v.values, assignment{
+		field:  v.field,
+		value:  value,
+		file:   v.file,
+		source: "flag -" + v.field.flag,
+	})
+
+	return nil
+}
+
+func (v *flagValue) IsBoolFlag() bool {
+	return v.field.value.Kind() == reflect.Bool
+}
+
+// Load defines the flags of the fields of the config on flag.CommandLine,
+// parses the command line and loads the config over its defaults.
+// The config file is set by -config or PREFIX_CONFIG.
+// With -print-config the loaded config is printed and the program exits.
+func Load(cfg interface{}, envPrefix string) error {
+	configFile := flag.String("config", os.Getenv(envPrefix+"CONFIG"),
+		"YAML config file, "+envPrefix+"CONFIG by default")
+	printConfig := flag.Bool("print-config", false, "print the loaded config and exit")
+
+	fs := fields(reflect.ValueOf(cfg).Elem(), "", envPrefix)
+
+	var flags []assignment
+
+	for _, f := range fs {
+		if f.secret() != nil {
+			flag.Var(&flagValue{field: f, file: true, values: &flags}, f.flag+"-file",
+				"file of the "+f.help+", or set "+f.env)
+
+			continue
+		}
+
+		v := &flagValue{field: f, values: &flags}
+		if !f.value.IsZero() {
+			v.def = format(f.value)
+		}
+
+		flag.Var(v, f.flag, f.help)
+	}
+
+	flag.Parse()
+
+	var layers []assignment
+
+	if *configFile != "" {
+		file, err := readFile(*configFile, fs)
+		if err != nil {
+			return err
+		}
+
+		layers = append(layers, file...)
+	}
+
+	layers = append(layers, environment(fs)...)
+	layers = append(layers, flags...)
+
+	for i := range layers {
+		if err := layers[i].apply(); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range fs {
+		if s := f.secret(); s != nil {
+			if err := s.read(); err != nil {
+				return fmt.Errorf("%s: %w", f.key, err)
+			}
+		}
+	}
+
+	if *printConfig {
+		if err := Print(os.Stdout, cfg, envPrefix); err != nil {
+			return err
+		}
+	}
+
+	if v, ok := cfg.(Validator); ok {
+		check := &Check{}
+		v.Validate(check)
+
+		if err := check.Err(); err != nil {
+			return err
+		}
+	}
+
+	if *printConfig {
+		os.Exit(0)
+	}
+
+	return nil
+}
+
+// environment returns the values of the environment variables of the fields.
+func environment(fs []*field) []assignment {
+	var values []assignment
+
+	for _, f := range fs {
+		if value, ok := os.LookupEnv(f.env); ok {
+			values = append(values, assignment{field: f, value: value, source: "env " + f.env})
+		}
+
+		if f.secret() == nil {
+			continue
+		}
+
+		if file, ok := os.LookupEnv(f.env + "_FILE"); ok {
+			values = append(values, assignment{field: f, value: file, file: true, source: "env " + f.env + "_FILE"})
+		}
+	}
+
+	return values
+}
+
+// format returns the value of a field as it is parsed.
+func format(v reflect.Value) string {
+	switch {
+	case v.Type() == durationType:
+		return time.Duration(v.Int()).String()
+	case v.Kind() == reflect.Slice:
+		return strings.Join(v.Interface().([]string), ",")
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+// Print writes the config as YAML, the secrets masked.
+func Print(w io.Writer, cfg interface{}, envPrefix string) error {
+	doc, err := encode(fields(reflect.ValueOf(cfg).Elem(), "", envPrefix))
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(doc)
+
+	return err
+}
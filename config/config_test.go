@@ -0,0 +1,182 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testPrefix = "TEST_"
+
+type testConfig struct {
+	Addr     string        `yaml:"addr" help:"address to listen on"`
+	Timeout  time.Duration `yaml:"timeout"`
+	Tags     []string      `yaml:"tags"`
+	Debug    bool          `yaml:"debug"`
+	Password Secret        `yaml:"password"`
+	TLS      struct {
+		ClientCA string `yaml:"client_ca" flag:"client-ca"`
+	} `yaml:"tls"`
+}
+
+func (c *testConfig) Validate(check *Check) {
+	check.Addr("addr", c.Addr)
+}
+
+// load loads the config over the defaults from the file content, the environment and the flags,
+// on a new flag.CommandLine.
+func load(t *testing.T, file string, env map[string]string, args ...string) (*testConfig, error) {
+	t.Helper()
+
+	commandLine, osArgs := flag.CommandLine, os.Args
+	t.Cleanup(func() {
+		flag.CommandLine, os.Args = commandLine, osArgs
+	})
+
+	flag.CommandLine = flag.NewFlagSet("test", flag.ContinueOnError)
+	os.Args = append([]string{"test"}, args...)
+
+	if file != "" {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		os.Args = append(os.Args, "-config", path)
+	}
+
+	for key, value := range env {
+		t.Setenv(key, value)
+	}
+
+	cfg := &testConfig{Addr: "localhost:1", Timeout: time.Second, Tags: []string{"default"}}
+
+	return cfg, Load(cfg, testPrefix)
+}
+
+func TestLoadLayers(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(secretFile, []byte("from file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		file     string
+		env      map[string]string
+		args     []string
+		addr     string
+		timeout  time.Duration
+		tags     []string
+		clientCA string
+		password string
+	}{
+		{
+			name:    "defaults",
+			addr:    "localhost:1",
+			timeout: time.Second,
+			tags:    []string{"default"},
+		},
+		{
+			name:     "file",
+			file:     "addr: localhost:2\ntimeout: 2s\ntags: [a, b]\ntls:\n  client_ca: ca.pem\n",
+			addr:     "localhost:2",
+			timeout:  2 * time.Second,
+			tags:     []string{"a", "b"},
+			clientCA: "ca.pem",
+		},
+		{
+			name:     "env over file",
+			file:     "addr: localhost:2\ntimeout: 2s\n",
+			env:      map[string]string{"TEST_ADDR": "localhost:3", "TEST_TLS_CLIENT_CA": "env.pem", "TEST_TAGS": "c, d,"},
+			addr:     "localhost:3",
+			timeout:  2 * time.Second,
+			tags:     []string{"c", "d"},
+			clientCA: "env.pem",
+		},
+		{
+			name:     "flags over env",
+			file:     "addr: localhost:2\n",
+			env:      map[string]string{"TEST_ADDR": "localhost:3", "TEST_TIMEOUT": "3s"},
+			args:     []string{"-addr", "localhost:4", "-client-ca", "flag.pem"},
+			addr:     "localhost:4",
+			timeout:  3 * time.Second,
+			tags:     []string{"default"},
+			clientCA: "flag.pem",
+		},
+		{
+			name:     "secret of env",
+			env:      map[string]string{"TEST_PASSWORD": "from env"},
+			addr:     "localhost:1",
+			timeout:  time.Second,
+			tags:     []string{"default"},
+			password: "from env",
+		},
+		{
+			name:     "secret file of the config file",
+			file:     "password_file: " + secretFile + "\n",
+			addr:     "localhost:1",
+			timeout:  time.Second,
+			tags:     []string{"default"},
+			password: "from file",
+		},
+		{
+			name:     "secret file flag over env",
+			env:      map[string]string{"TEST_PASSWORD": "from env"},
+			args:     []string{"-password-file", secretFile},
+			addr:     "localhost:1",
+			timeout:  time.Second,
+			tags:     []string{"default"},
+			password: "from file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := load(t, tt.file, tt.env, tt.args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if cfg.Addr != tt.addr || cfg.Timeout != tt.timeout || cfg.TLS.ClientCA != tt.clientCA {
+				t.Errorf("Load() = %s %s %q, want %s %s %q",
+					cfg.Addr, cfg.Timeout, cfg.TLS.ClientCA, tt.addr, tt.timeout, tt.clientCA)
+			}
+
+			if !reflect.DeepEqual(cfg.Tags, tt.tags) {
+				t.Errorf("Tags = %q, want %q", cfg.Tags, tt.tags)
+			}
+
+			if cfg.Password.Value() != tt.password {
+				t.Errorf("Password = %q, want %q", cfg.Password.Value(), tt.password)
+			}
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		env  map[string]string
+	}{
+		{name: "unknown key", file: "port: 1\n"},
+		{name: "secret in the config file", file: "password: s3cr3t\n"},
+		{name: "bad duration", file: "timeout: soon\n"},
+		{name: "not a mapping", file: "- addr\n"},
+		{name: "bad env", env: map[string]string{"TEST_DEBUG": "maybe"}},
+		{name: "missing secret file", env: map[string]string{"TEST_PASSWORD_FILE": "/nonexistent"}},
+		{name: "invalid", env: map[string]string{"TEST_ADDR": "no port"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := load(t, tt.file, tt.env); err == nil {
+				t.Error("Load() returned no error")
+			}
+		})
+	}
+}
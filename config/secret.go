@@ -0,0 +1,48 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"strings"
+)
+
+var secretType = reflect.TypeOf(Secret{})
+
+// Secret is a password or a token. It is set by its environment variable,
+// or read from a file set by the variable with the _FILE suffix, the -NAME-file flag
+// or the NAME_file key of the config file, never by the config file or the flags themselves,
+// so it is neither kept with the configs nor seen in the process list.
+type Secret struct {
+	value string
+	file  string
+}
+
+// Value returns the secret.
+func (s Secret) Value() string {
+	return s.value
+}
+
+// String masks the secret, so it is not logged by accident.
+func (s Secret) String() string {
+	if s.value == "" {
+		return ""
+	}
+
+	return "******"
+}
+
+// read reads the secret of the file.
+func (s *Secret) read() error {
+	if s.file == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.file)
+	if err != nil {
+		return err
+	}
+
+	s.value = strings.TrimRight(string(data), "\r\n")
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// readFile returns the values of the fields set by the YAML config file.
+func readFile(path string, fs []*field) ([]assignment, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err = yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	byKey := map[string]*field{}
+
+	for _, f := range fs {
+		if f.secret() != nil {
+			byKey[f.key+"_file"] = f
+		} else {
+			byKey[f.key] = f
+		}
+	}
+
+	var values []assignment
+
+	err = walk(doc.Content[0], "", func(key string, node *yaml.Node, value string) error {
+		f, ok := byKey[key]
+		if !ok {
+			if _, ok = byKey[key+"_file"]; ok {
+				return fmt.Errorf("%s:%d: %s is a secret, set %s_file or its environment variable",
+					path, node.Line, key, key)
+			}
+
+			return fmt.Errorf("%s:%d: unknown key %s", path, node.Line, key)
+		}
+
+		values = append(values, assignment{
+			field:  f,
+			value:  value,
+			file:   f.secret() != nil,
+			source: fmt.Sprintf("%s:%d", path, node.Line),
+		})
+
+		return nil
+	})
+
+	return values, err
+}
+
+// walk calls fn for every value of the mapping with its dotted key,
+// the sequences are joined by commas.
+func walk(node *yaml.Node, prefix string, fn func(key string, node *yaml.Node, value string) error) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: want a mapping of the keys", node.Line)
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := prefix+node.Content[i].Value, node.Content[i+1]
+
+		var err error
+
+		switch value.Kind {
+		case yaml.MappingNode:
+			err = walk(value, key+".", fn)
+		case yaml.SequenceNode:
+			items := make([]string, 0, len(value.Content))
+
+			for _, item := range value.Content {
+				if item.Kind != yaml.ScalarNode {
+					return fmt.Errorf("line %d: %s: want a list of values", item.Line, key)
+				}
+
+				items = append(items, item.Value)
+			}
+
+			err = fn(key, value, strings.Join(items, ","))
+		case yaml.ScalarNode:
+			if value.Tag == "!!null" {
+				continue
+			}
+
+			err = fn(key, value, value.Value)
+		default:
+			err = fmt.Errorf("line %d: %s: unsupported value", value.Line, key)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// encode returns the YAML of the fields, keeping their order.
+func encode(fs []*field) ([]byte, error) {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+
+	for _, f := range fs {
+		keys := strings.Split(f.key, ".")
+		parent := root
+
+		for _, key := range keys[:len(keys)-1] {
+			parent = mapping(parent, key)
+		}
+
+		name := keys[len(keys)-1]
+		value := &yaml.Node{}
+
+		if s := f.secret(); s != nil {
+			if s.file != "" {
+				if err := appendKey(parent, name+"_file", value, s.file); err != nil {
+					return nil, err
+				}
+
+				value = &yaml.Node{}
+			}
+
+			if err := appendKey(parent, name, value, s.String()); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		if err := appendKey(parent, name, value, f.value.Interface()); err != nil {
+			return nil, err
+		}
+	}
+
+	return yaml.Marshal(root)
+}
+
+func appendKey(parent *yaml.Node, key string, value *yaml.Node, v interface{}) error {
+	if err := value.Encode(v); err != nil {
+		return err
+	}
+
+	if value.Kind == yaml.SequenceNode {
+		value.Style = yaml.FlowStyle
+	}
+
+	parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+
+	return nil
+}
+
+// mapping returns the mapping of the key of the parent, adding it when it is not there.
+func mapping(parent *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(parent.Content); i += 2 {
+		if parent.Content[i].Value == key {
+			return parent.Content[i+1]
+		}
+	}
+
+	child := &yaml.Node{Kind: yaml.MappingNode}
+	parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, child)
+
+	return child
+}
@@ -20,12 +20,13 @@ CERT_PATH=ssl
 #SERVER_CRT=$CERT_PATH/server.crt
 #SERVER_PEM=$CERT_PATH/server.pem
 
-rm -rf $CERT_PATH
-mkdir -p $CERT_PATH
-cd $CERT_PATH
-
-# go get github.com/jsha/minica
-minica --domains localhost
+# the development CA of cmd/devca makes ssl/minica.pem on the first run
+# and renews the certificates with it on the next ones,
+# remove the ssl directory to start over with a new CA
+go run ./cmd/devca -dir $CERT_PATH -domains localhost -ip-addresses 127.0.0.1,::1
+# client certificate for the servers started with -client-ca ssl/minica.pem,
+# its common name "client" is the caller
+go run ./cmd/devca -dir $CERT_PATH -client -domains client
 
 # Step 1: Generate the root certificate authority key with the set password + Trust Certificate (ca.crt)
 #openssl genrsa -des3 -passout pass:$CA_PASSWORD -out $CA_KEY 4096
@@ -2,4 +2,6 @@
 
 protoc greet/greetpb/greet.proto --go_out=plugins=grpc:.
 protoc calculator/calculatorpb/calculator.proto --go_out=plugins=grpc:.
-protoc blog/blogpb/blog.proto --go_out=plugins=grpc:.
\ No newline at end of file
+protoc blog/blogpb/blog.proto --go_out=plugins=grpc:.
+protoc blog/blogpb/comment.proto --go_out=plugins=grpc:.
+protoc auth/authpb/auth.proto --go_out=plugins=grpc:.
\ No newline at end of file
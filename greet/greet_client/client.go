@@ -8,19 +8,27 @@ import (
 	"log"
 	"time"
 
+	"github.com/sergeyzalunin/grpc-go-course/clientkit"
+	"github.com/sergeyzalunin/grpc-go-course/config"
 	"github.com/sergeyzalunin/grpc-go-course/greet/greetpb"
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
 )
 
+// envPrefix is the prefix of the environment variables of the client config.
+const envPrefix = "GREET_"
+
 func main() {
-	fmt.Println("Hi from client")
+	cfg := clientkit.DefaultConfig("localhost:50051")
+	cfg.TLS.Enabled = true
 
-	opts := getTLSClientOptions(true)
+	if err := config.Load(&cfg, envPrefix); err != nil {
+		log.Fatal(err)
+	}
 
-	cc, err := grpc.Dial("localhost:50051", opts)
+	fmt.Println("Hi from client")
+
+	cc, err := clientkit.Dial(cfg)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -36,28 +44,6 @@ func main() {
 	doGreetWithDeadline(c)
 }
 
-// getTLSClientOptions returns already setup TLS dial option
-// when tls parameter is true
-// to use it, start gen_cert.sh manualy to generate certificates
-func getTLSClientOptions(tls bool) grpc.DialOption {
-	var opts grpc.DialOption
-
-	if tls {
-		certFile := "../../ssl/minica.pem" // Certificate Authority Trust certificate
-
-		creds, sslErr := credentials.NewClientTLSFromFile(certFile, "")
-		if sslErr == nil {
-			opts = grpc.WithTransportCredentials(creds)
-		} else {
-			log.Fatalf("Filed loading certificates: %v", sslErr)
-		}
-	} else {
-		opts = grpc.WithInsecure()
-	}
-
-	return opts
-}
-
 func doUnary(c greetpb.GreetServiceClient) {
 	req := &greetpb.GreetRequest{
 		Greeting: &greetpb.Greeting{
@@ -71,7 +57,7 @@ func doUnary(c greetpb.GreetServiceClient) {
 		log.Fatal(err)
 	}
 
-	fmt.Printf(res.Result)
+	fmt.Println(res.Result)
 }
 
 func doServerStreaming(c greetpb.GreetServiceClient) {
@@ -6,56 +6,61 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"net"
 	"runtime"
 	"strconv"
 	"time"
 
+	"github.com/sergeyzalunin/grpc-go-course/config"
 	"github.com/sergeyzalunin/grpc-go-course/greet/greetpb"
-	"google.golang.org/grpc"
+	"github.com/sergeyzalunin/grpc-go-course/serverkit"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
-	"google.golang.org/grpc/reflection"
 )
 
+// envPrefix is the prefix of the environment variables of the server config.
+const envPrefix = "GREET_SERVER_"
+
+// serverConfig is the config of the server loaded by the config package.
+type serverConfig struct {
+	Server   serverkit.Config `yaml:",inline"`
+	AuthKeys string           `yaml:"auth_keys" help:"directory of the AuthService token keys, no authentication when empty"`
+}
+
+func (c *serverConfig) Validate(check *config.Check) {
+	c.Server.Validate(check)
+	check.File("auth_keys", c.AuthKeys)
+}
+
 func main() {
+	// TLS is off unless the client certificates or the dev certificate need it
+	cfg := &serverConfig{Server: serverkit.DefaultConfig("greet", "localhost:50051")}
+	if err := config.Load(cfg, envPrefix); err != nil {
+		log.Fatal(err)
+	}
+
 	fmt.Println("Hi")
 
-	listener, err := net.Listen("tcp", "localhost:50051")
+	// the keys are watched while the server runs
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	interceptors, err := serverkit.AuthInterceptors(ctx, cfg.Server, cfg.AuthKeys)
 	if err != nil {
-		fmt.Println(err)
+		log.Fatal(err)
 	}
 
-	opts := getTLSServerOptions(false)
-	s := grpc.NewServer(opts...)
-	greetpb.RegisterGreetServiceServer(s, &server{})
-	reflection.Register(s)
-	
-	if err = s.Serve(listener); err != nil {
-		fmt.Print(err)
-	}
-}
+	cfg.Server.Interceptors = interceptors
 
-// getTLSServerOptions returns already setup TLS server option
-// when tls parameter is true
-// to use it, start gen_cert.sh manualy to generate certificates
-func getTLSServerOptions(tls bool) []grpc.ServerOption {
-	opts := []grpc.ServerOption{}
-
-	if tls {
-		certFile := "../../ssl/localhost/cert.pem"
-		keyFile := "../../ssl/localhost/key.pem"
-
-		creds, sslErr := credentials.NewServerTLSFromFile(certFile, keyFile)
-		if sslErr == nil {
-			opts = append(opts, grpc.Creds(creds))
-		} else {
-			log.Fatalf("Filed loading certificates: %v", sslErr)
-		}
+	s, err := serverkit.New(cfg.Server)
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	return opts
+	greetpb.RegisterGreetServiceServer(s.GRPC, &server{})
+
+	if err = s.Run(ctx); err != nil {
+		log.Fatal(err)
+	}
 }
 
 type server struct{}
@@ -0,0 +1,197 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// monitor records the metrics of a call from its start to its end.
+type monitor struct {
+	m     *Metrics
+	call  call
+	start time.Time
+	once  sync.Once
+}
+
+func (m *Metrics) start(typ, fullMethod string) *monitor {
+	c := newCall(typ, fullMethod)
+	m.inFlight.With(c.labels).Inc()
+
+	return &monitor{m: m, call: c, start: time.Now()}
+}
+
+func (mon *monitor) received() {
+	mon.m.received.With(mon.call.labels).Inc()
+}
+
+func (mon *monitor) sent() {
+	mon.m.sent.With(mon.call.labels).Inc()
+}
+
+// end records the end of the call with the error, only once.
+func (mon *monitor) end(err error) {
+	mon.once.Do(func() {
+		mon.m.inFlight.With(mon.call.labels).Dec()
+		mon.m.handling.With(mon.call.labels).Observe(time.Since(mon.start).Seconds())
+
+		labels := prometheus.Labels{"grpc_code": code(err).String()}
+		for k, v := range mon.call.labels {
+			labels[k] = v
+		}
+
+		mon.m.handled.With(labels).Inc()
+	})
+}
+
+// code returns the status code of the error as gRPC sends it,
+// the context errors returned by the handlers are Canceled or DeadlineExceeded.
+func code(err error) codes.Code {
+	if st, ok := status.FromError(err); ok {
+		return st.Code()
+	}
+
+	return status.FromContextError(err).Code()
+}
+
+// UnaryServerInterceptor records the metrics of the unary calls of a server.
+func (m *Metrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		mon := m.start(unary, info.FullMethod)
+		mon.received()
+
+		res, err := handler(ctx, req)
+		if err == nil {
+			mon.sent()
+		}
+
+		mon.end(err)
+
+		return res, err
+	}
+}
+
+// StreamServerInterceptor records the metrics of the streams of a server.
+func (m *Metrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		mon := m.start(callType(info.IsClientStream, info.IsServerStream), info.FullMethod)
+
+		err := handler(srv, &monitoredServerStream{stream, mon})
+		mon.end(err)
+
+		return err
+	}
+}
+
+// monitoredServerStream counts the messages of a server stream.
+type monitoredServerStream struct {
+	grpc.ServerStream
+	mon *monitor
+}
+
+func (s *monitoredServerStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.mon.sent()
+	}
+
+	return err
+}
+
+func (s *monitoredServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.mon.received()
+	}
+
+	return err
+}
+
+// UnaryClientInterceptor records the metrics of the unary calls of a client.
+func (m *Metrics) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		mon := m.start(unary, method)
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil {
+			mon.sent()
+			mon.received()
+		}
+
+		mon.end(err)
+
+		return err
+	}
+}
+
+// StreamClientInterceptor records the metrics of the streams of a client.
+// A stream ends when gRPC finishes it: its status is received, its context is done
+// or its connection is closed. As gRPC documents, a stream the caller neither drains
+// nor cancels stays in flight until its connection is closed.
+func (m *Metrics) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		mon := m.start(callType(desc.ClientStreams, desc.ServerStreams), method)
+
+		stream, err := streamer(ctx, desc, cc, method, append(opts, grpc.OnFinish(mon.end))...)
+		if err != nil {
+			mon.end(err)
+
+			return nil, err
+		}
+
+		return &monitoredClientStream{stream, mon}, nil
+	}
+}
+
+// monitoredClientStream counts the messages of a client stream.
+type monitoredClientStream struct {
+	grpc.ClientStream
+	mon *monitor
+}
+
+func (s *monitoredClientStream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		s.mon.sent()
+	}
+
+	return err
+}
+
+func (s *monitoredClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil {
+		s.mon.received()
+	}
+
+	return err
+}
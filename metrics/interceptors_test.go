@@ -0,0 +1,294 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sergeyzalunin/grpc-go-course/greet/greetpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// greetServer fails Greet, streams three greetings and waits for the end of GreetEveryone.
+type greetServer struct {
+	greetpb.UnimplementedGreetServiceServer
+}
+
+func (greetServer) Greet(context.Context, *greetpb.GreetRequest) (*greetpb.GreetResponse, error) {
+	return nil, status.Error(codes.InvalidArgument, "no greeting")
+}
+
+func (greetServer) GreetManyTimes(_ *greetpb.GreetManyTimesRequest, stream greetpb.GreetService_GreetManyTimesServer) error {
+	for i := 0; i < 3; i++ {
+		if err := stream.Send(&greetpb.GreetManyTimesResponse{}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (greetServer) LongGreet(stream greetpb.GreetService_LongGreetServer) error {
+	for {
+		_, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(&greetpb.LongGreetResponse{})
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (greetServer) GreetEveryone(stream greetpb.GreetService_GreetEveryoneServer) error {
+	if err := stream.Send(&greetpb.GreetEveryoneResponse{}); err != nil {
+		return err
+	}
+
+	<-stream.Context().Done()
+
+	return stream.Context().Err()
+}
+
+// serve returns a client connection of the greet server, both recording their metrics.
+func serve(t *testing.T) (*grpc.ClientConn, *Metrics, *Metrics) {
+	t.Helper()
+
+	server, client := NewServerMetrics(prometheus.NewRegistry()), NewClientMetrics(prometheus.NewRegistry())
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(server.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(server.StreamServerInterceptor()),
+	)
+	greetpb.RegisterGreetServiceServer(s, &greetServer{})
+
+	go func() {
+		_ = s.Serve(lis)
+	}()
+
+	t.Cleanup(s.Stop)
+
+	cc, err := grpc.NewClient("passthrough:///bufnet", append(client.DialOptions(),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		cc.Close()
+	})
+
+	return cc, server, client
+}
+
+// counts are the metrics of a call of a side.
+type counts struct {
+	handled  float64 // by the code
+	inFlight float64
+	received float64
+	sent     float64
+}
+
+func countsOf(m *Metrics, typ, method string, code codes.Code) counts {
+	c := newCall(typ, "/greet.GreetService/"+method)
+
+	labels := prometheus.Labels{"grpc_code": code.String()}
+	for k, v := range c.labels {
+		labels[k] = v
+	}
+
+	return counts{
+		handled:  testutil.ToFloat64(m.handled.With(labels)),
+		inFlight: testutil.ToFloat64(m.inFlight.With(c.labels)),
+		received: testutil.ToFloat64(m.received.With(c.labels)),
+		sent:     testutil.ToFloat64(m.sent.With(c.labels)),
+	}
+}
+
+// eventually waits for the counts of the call, as a server ends a stream
+// after its client receives the status.
+func eventually(t *testing.T, m *Metrics, typ, method string, code codes.Code, want counts) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+
+	for {
+		got := countsOf(m, typ, method, code)
+		if got == want {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			t.Errorf("%s %s: got %+v, want %+v", typ, method, got, want)
+
+			return
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestInterceptors(t *testing.T) {
+	tests := []struct {
+		name   string
+		typ    string
+		method string
+		call   func(ctx context.Context, c greetpb.GreetServiceClient) error
+		code   codes.Code
+		server counts
+		client counts
+	}{
+		{
+			name:   "unary error",
+			typ:    unary,
+			method: "Greet",
+			call: func(ctx context.Context, c greetpb.GreetServiceClient) error {
+				_, err := c.Greet(ctx, &greetpb.GreetRequest{})
+
+				return err
+			},
+			code:   codes.InvalidArgument,
+			server: counts{handled: 1, received: 1},
+			client: counts{handled: 1},
+		},
+		{
+			name:   "server stream",
+			typ:    serverStream,
+			method: "GreetManyTimes",
+			call: func(ctx context.Context, c greetpb.GreetServiceClient) error {
+				stream, err := c.GreetManyTimes(ctx, &greetpb.GreetManyTimesRequest{})
+				if err != nil {
+					return err
+				}
+
+				for {
+					if _, err = stream.Recv(); errors.Is(err, io.EOF) {
+						return nil
+					} else if err != nil {
+						return err
+					}
+				}
+			},
+			code:   codes.OK,
+			server: counts{handled: 1, received: 1, sent: 3},
+			client: counts{handled: 1, received: 3, sent: 1},
+		},
+		{
+			name:   "client stream",
+			typ:    clientStream,
+			method: "LongGreet",
+			call: func(ctx context.Context, c greetpb.GreetServiceClient) error {
+				stream, err := c.LongGreet(ctx)
+				if err != nil {
+					return err
+				}
+
+				for i := 0; i < 2; i++ {
+					if err = stream.Send(&greetpb.LongGreetRequest{}); err != nil {
+						return err
+					}
+				}
+
+				_, err = stream.CloseAndRecv()
+
+				return err
+			},
+			code:   codes.OK,
+			server: counts{handled: 1, received: 2, sent: 1},
+			client: counts{handled: 1, received: 1, sent: 2},
+		},
+		{
+			// the caller cancels the stream without receiving its end
+			name:   "abandoned bidi stream",
+			typ:    bidiStream,
+			method: "GreetEveryone",
+			call: func(ctx context.Context, c greetpb.GreetServiceClient) error {
+				ctx, cancel := context.WithCancel(ctx)
+				defer cancel()
+
+				stream, err := c.GreetEveryone(ctx)
+				if err != nil {
+					return err
+				}
+
+				if _, err = stream.Recv(); err != nil {
+					return err
+				}
+
+				return status.Error(codes.Canceled, "abandoned")
+			},
+			code:   codes.Canceled,
+			server: counts{handled: 1, sent: 1},
+			client: counts{handled: 1, received: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc, server, client := serve(t)
+
+			err := tt.call(context.Background(), greetpb.NewGreetServiceClient(cc))
+			if status.Code(err) != tt.code {
+				t.Fatalf("call error = %v, want %v", err, tt.code)
+			}
+
+			eventually(t, client, tt.typ, tt.method, tt.code, tt.client)
+			eventually(t, server, tt.typ, tt.method, tt.code, tt.server)
+		})
+	}
+}
+
+// A stream the caller neither drains nor cancels ends with its connection.
+func TestInterceptorsUndrainedStream(t *testing.T) {
+	cc, server, client := serve(t)
+
+	stream, err := greetpb.NewGreetServiceClient(cc).GreetEveryone(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = stream.Recv(); err != nil {
+		t.Fatal(err)
+	}
+
+	eventually(t, client, bidiStream, "GreetEveryone", codes.Canceled, counts{inFlight: 1, received: 1})
+
+	cc.Close()
+
+	eventually(t, client, bidiStream, "GreetEveryone", codes.Canceled, counts{handled: 1, received: 1})
+	eventually(t, server, bidiStream, "GreetEveryone", codes.Canceled, counts{handled: 1, sent: 1})
+}
+
+func TestNewCall(t *testing.T) {
+	tests := []struct {
+		fullMethod string
+		service    string
+		method     string
+	}{
+		{"/blog.BlogService/ListBlog", "blog.BlogService", "ListBlog"},
+		{"/grpc.health.v1.Health/Check", "grpc.health.v1.Health", "Check"},
+		{"nonsense", "unknown", "unknown"},
+	}
+
+	for _, tt := range tests {
+		c := newCall(unary, tt.fullMethod)
+		if c.labels["grpc_service"] != tt.service || c.labels["grpc_method"] != tt.method {
+			t.Errorf("newCall(%q) = %v, want %s %s", tt.fullMethod, c.labels, tt.service, tt.method)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+// Package metrics records the Prometheus metrics of the gRPC calls of the servers and the clients:
+// the calls by method and status code, their latency, the calls in flight
+// and the messages of the streams.
+package metrics
+
+import (
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+)
+
+// the types of the calls, the grpc_type label
+const (
+	unary        = "unary"
+	clientStream = "client_stream"
+	serverStream = "server_stream"
+	bidiStream   = "bidi_stream"
+)
+
+// handlingBuckets are the buckets of the latencies in seconds,
+// up to a minute for the long streams such as GreetManyTimes and ListBlog.
+var handlingBuckets = []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60}
+
+// Metrics are the metrics of the calls of a server or of a client.
+type Metrics struct {
+	handled  *prometheus.CounterVec
+	handling *prometheus.HistogramVec
+	inFlight *prometheus.GaugeVec
+	received *prometheus.CounterVec
+	sent     *prometheus.CounterVec
+}
+
+// NewServerMetrics registers the grpc_server_* metrics to the registerer.
+func NewServerMetrics(reg prometheus.Registerer) *Metrics {
+	return newMetrics(reg, "server", "handled by the server", "received by the server", "sent by the server")
+}
+
+// NewClientMetrics registers the grpc_client_* metrics to the registerer.
+func NewClientMetrics(reg prometheus.Registerer) *Metrics {
+	return newMetrics(reg, "client", "completed by the client", "received by the client", "sent by the client")
+}
+
+func newMetrics(reg prometheus.Registerer, side, handled, received, sent string) *Metrics {
+	labels := []string{"grpc_type", "grpc_service", "grpc_method"}
+
+	m := &Metrics{
+		handled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "grpc",
+			Subsystem: side,
+			Name:      "handled_total",
+			Help:      "Number of the calls " + handled + " by their status code.",
+		}, append(labels, "grpc_code")),
+		handling: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "grpc",
+			Subsystem: side,
+			Name:      "handling_seconds",
+			Help:      "Latency of the calls " + handled + ", to the end of the streams.",
+			Buckets:   handlingBuckets,
+		}, labels),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "grpc",
+			Subsystem: side,
+			Name:      "in_flight",
+			Help:      "Number of the calls in flight.",
+		}, labels),
+		received: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "grpc",
+			Subsystem: side,
+			Name:      "msg_received_total",
+			Help:      "Number of the messages " + received + ".",
+		}, labels),
+		sent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "grpc",
+			Subsystem: side,
+			Name:      "msg_sent_total",
+			Help:      "Number of the messages " + sent + ".",
+		}, labels),
+	}
+
+	reg.MustRegister(m.handled, m.handling, m.inFlight, m.received, m.sent)
+
+	return m
+}
+
+// NewRegistry returns a registry with the Go runtime and the process metrics.
+func NewRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return reg
+}
+
+// Handler serves the metrics of the gatherer on /metrics.
+func Handler(g prometheus.Gatherer) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(g, promhttp.HandlerOpts{}))
+
+	return mux
+}
+
+// ServeClient records the metrics of the calls of a client connection
+// and serves them on the address while the process runs, none when the address is empty.
+// It returns the dial options of the connection recording the metrics.
+func ServeClient(addr string) ([]grpc.DialOption, error) {
+	if addr == "" {
+		return nil, nil
+	}
+
+	registry := NewRegistry()
+	m := NewClientMetrics(registry)
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	srv := &http.Server{
+		Handler:           Handler(registry),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		if err := srv.Serve(listener); err != nil {
+			log.Printf("Metrics HTTP server: %v", err)
+		}
+	}()
+
+	log.Printf("Metrics served on http://%s/metrics", listener.Addr())
+
+	return m.DialOptions(), nil
+}
+
+// callType returns the grpc_type of the call of the stream.
+func callType(clientStreams, serverStreams bool) string {
+	switch {
+	case clientStreams && serverStreams:
+		return bidiStream
+	case clientStreams:
+		return clientStream
+	case serverStreams:
+		return serverStream
+	default:
+		return unary
+	}
+}
+
+// call is the labels of a call.
+type call struct {
+	labels prometheus.Labels
+}
+
+// newCall returns the labels of the call of the full method: /blog.BlogService/ListBlog.
+func newCall(typ, fullMethod string) call {
+	service, method := "unknown", "unknown"
+
+	if i := strings.LastIndex(fullMethod, "/"); i > 0 {
+		service, method = fullMethod[1:i], fullMethod[i+1:]
+	}
+
+	return call{prometheus.Labels{
+		"grpc_type":    typ,
+		"grpc_service": service,
+		"grpc_method":  method,
+	}}
+}
+
+// DialOptions returns the options of a client connection recording the metrics of its calls.
+func (m *Metrics) DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(m.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(m.StreamClientInterceptor()),
+	}
+}
@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestServeClient(t *testing.T) {
+	if opts, err := ServeClient(""); opts != nil || err != nil {
+		t.Errorf("ServeClient(\"\") = %v, %v, want no options", opts, err)
+	}
+
+	if _, err := ServeClient("localhost"); err == nil {
+		t.Error("ServeClient() of an address without a port succeeded")
+	}
+
+	// a free port for the metrics
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := listener.Addr().String()
+	listener.Close()
+
+	opts, err := ServeClient(addr)
+	if err != nil || len(opts) == 0 {
+		t.Fatalf("ServeClient() = %v, %v, want the dial options", opts, err)
+	}
+
+	res, err := http.Get("http://" + addr + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.StatusCode != http.StatusOK || !strings.Contains(string(body), "go_goroutines") {
+		t.Errorf("/metrics = %d %q, want the runtime metrics", res.StatusCode, body)
+	}
+}
@@ -0,0 +1,46 @@
+package serverkit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sergeyzalunin/grpc-go-course/auth"
+	"google.golang.org/grpc"
+)
+
+// keysInterval is how often the token keys are reloaded.
+const keysInterval = time.Minute
+
+// AuthInterceptors returns the interceptors identifying the callers
+// by their client certificates when the server verifies them,
+// or authenticating the tokens of the service by the keys of the directory,
+// which are reloaded until the context is done.
+// There are no interceptors when the directory is empty.
+func AuthInterceptors(ctx context.Context, config Config, keysDir string) (Interceptors, error) {
+	if config.TLS.ClientCAFile != "" {
+		return Interceptors{
+			Unary:  []grpc.UnaryServerInterceptor{auth.CertUnaryServerInterceptor()},
+			Stream: []grpc.StreamServerInterceptor{auth.CertStreamServerInterceptor()},
+		}, nil
+	}
+
+	if keysDir == "" {
+		return Interceptors{}, nil
+	}
+
+	keys, err := auth.LoadKeys(keysDir)
+	if err != nil {
+		return Interceptors{}, fmt.Errorf("loading keys: %w", err)
+	}
+
+	// the rotated keys are picked up without a restart
+	go keys.Watch(ctx, keysInterval)
+
+	verifier := &auth.TokenVerifier{Keys: keys, Audience: config.Name}
+
+	return Interceptors{
+		Unary:  []grpc.UnaryServerInterceptor{auth.UnaryServerInterceptor(verifier)},
+		Stream: []grpc.StreamServerInterceptor{auth.StreamServerInterceptor(verifier)},
+	}, nil
+}
@@ -0,0 +1,47 @@
+package serverkit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sergeyzalunin/grpc-go-course/auth"
+)
+
+func TestAuthInterceptors(t *testing.T) {
+	keysDir := t.TempDir()
+	if err := auth.GenerateKey(keysDir, "k1"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		clientCAFile string
+		keysDir      string
+		want         int // interceptors of each kind
+		wantErr      bool
+	}{
+		{"none", "", "", 0, false},
+		{"client certificates", "ca.pem", keysDir, 1, false},
+		{"tokens", "", keysDir, 1, false},
+		{"no keys", "", t.TempDir(), 0, true},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig("greet", "localhost:0")
+			config.TLS.ClientCAFile = tt.clientCAFile
+
+			got, err := AuthInterceptors(ctx, config, tt.keysDir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AuthInterceptors() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			if len(got.Unary) != tt.want || len(got.Stream) != tt.want {
+				t.Errorf("AuthInterceptors() = %d unary and %d stream, want %d", len(got.Unary), len(got.Stream), tt.want)
+			}
+		})
+	}
+}
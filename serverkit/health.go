@@ -0,0 +1,146 @@
+package serverkit
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultProbeInterval is how often the dependencies are probed.
+const DefaultProbeInterval = 10 * time.Second
+
+// Probe checks a dependency of the services, such as a database.
+type Probe func(ctx context.Context) error
+
+type probe struct {
+	name     string
+	check    Probe
+	services []string // the services depending on it, all of them when empty
+	err      error    // of the last check
+}
+
+// AddProbe adds a dependency of the services, of all the services when none is given,
+// they are NOT_SERVING while its probe fails. The probes are added before Run.
+func (s *Server) AddProbe(name string, check Probe, services ...string) {
+	s.probes = append(s.probes, &probe{
+		name:     name,
+		check:    check,
+		services: services,
+	})
+}
+
+// runProbes checks the dependencies every probe interval until the context is done.
+func (s *Server) runProbes(ctx context.Context) {
+	ticker := time.NewTicker(s.config.ProbeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.checkProbes(ctx)
+		}
+	}
+}
+
+// checkProbes checks the dependencies and sets the status of every service:
+// NOT_SERVING while a probe of its dependencies fails.
+// The status of the server, of the empty service name, is NOT_SERVING while any probe fails.
+func (s *Server) checkProbes(ctx context.Context) {
+	var anyFailing, allFailing bool
+
+	failing := map[string]bool{}
+
+	for _, p := range s.probes {
+		probeCtx, cancel := context.WithTimeout(ctx, s.config.ProbeInterval)
+		err := p.check(probeCtx)
+		cancel()
+
+		switch {
+		case err != nil && p.err == nil:
+			log.Printf("Probe %s failed: %v", p.name, err)
+		case err == nil && p.err != nil:
+			log.Printf("Probe %s recovered", p.name)
+		}
+
+		s.mu.Lock()
+		p.err = err
+		s.mu.Unlock()
+
+		if err == nil {
+			continue
+		}
+
+		anyFailing = true
+		allFailing = allFailing || len(p.services) == 0
+
+		for _, service := range p.services {
+			failing[service] = true
+		}
+	}
+
+	failing[""] = anyFailing
+
+	services := []string{""}
+	for service := range s.GRPC.GetServiceInfo() {
+		services = append(services, service)
+	}
+
+	for _, service := range services {
+		serving := healthpb.HealthCheckResponse_SERVING
+		if allFailing || failing[service] {
+			serving = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+
+		s.Health.SetServingStatus(service, serving)
+	}
+}
+
+// healthHandler serves the health of the server over HTTP for the orchestrators
+// not checking the gRPC health:
+// /healthz answers while the process runs, /readyz while the server or the service
+// of the service parameter is serving and lists the failing probes when it is not.
+func (s *Server) healthHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("/readyz", s.ready)
+
+	return mux
+}
+
+func (s *Server) ready(w http.ResponseWriter, r *http.Request) {
+	res, err := s.Health.Check(r.Context(), &healthpb.HealthCheckRequest{
+		Service: r.URL.Query().Get("service"),
+	})
+	if err != nil {
+		http.Error(w, status.Convert(err).Message(), http.StatusNotFound)
+
+		return
+	}
+
+	if res.GetStatus() == healthpb.HealthCheckResponse_SERVING {
+		fmt.Fprintln(w, res.GetStatus())
+
+		return
+	}
+
+	w.WriteHeader(http.StatusServiceUnavailable)
+	fmt.Fprintln(w, res.GetStatus())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, p := range s.probes {
+		if p.err != nil {
+			fmt.Fprintf(w, "%s: %v\n", p.name, p.err)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package serverkit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestCheckProbes(t *testing.T) {
+	const (
+		service = "grpc.health.v1.Health"
+		other   = "grpc.reflection.v1.ServerReflection"
+	)
+
+	serving, notServing := healthpb.HealthCheckResponse_SERVING, healthpb.HealthCheckResponse_NOT_SERVING
+
+	tests := []struct {
+		name    string
+		store   error // of the probe of all the services
+		cache   error // of the probe of the service
+		server  healthpb.HealthCheckResponse_ServingStatus
+		service healthpb.HealthCheckResponse_ServingStatus
+		other   healthpb.HealthCheckResponse_ServingStatus
+		body    string // of /readyz
+	}{
+		{"healthy", nil, nil, serving, serving, serving, "SERVING"},
+		{"service dependency", nil, errors.New("cache down"), notServing, notServing, serving, "cache: cache down"},
+		{"shared dependency", errors.New("store down"), nil, notServing, notServing, notServing, "store: store down"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New(DefaultConfig("blog", "localhost:0"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.listener.Close()
+			defer s.stop()
+
+			s.AddProbe("store", func(context.Context) error { return tt.store })
+			s.AddProbe("cache", func(context.Context) error { return tt.cache }, service)
+
+			s.checkProbes(context.Background())
+
+			statuses := map[string]healthpb.HealthCheckResponse_ServingStatus{"": tt.server, service: tt.service, other: tt.other}
+			for name, want := range statuses {
+				res, err := s.Health.Check(context.Background(), &healthpb.HealthCheckRequest{Service: name})
+				if err != nil || res.GetStatus() != want {
+					t.Errorf("Check(%q) = %v, %v, want %v", name, res, err, want)
+				}
+			}
+
+			wantCode := http.StatusOK
+			if tt.server != serving {
+				wantCode = http.StatusServiceUnavailable
+			}
+
+			code, body := get(t, s.healthHandler(), "/readyz")
+			if code != wantCode || !strings.Contains(body, tt.body) {
+				t.Errorf("/readyz = %d %q, want %d with %q", code, body, wantCode, tt.body)
+			}
+		})
+	}
+}
+
+func TestHealthHandler(t *testing.T) {
+	s, err := New(DefaultConfig("blog", "localhost:0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.listener.Close()
+	defer s.stop()
+
+	s.AddProbe("store", func(context.Context) error { return errors.New("store down") })
+	s.checkProbes(context.Background())
+
+	tests := []struct {
+		path string
+		code int
+	}{
+		// the process runs even though it is not ready
+		{"/healthz", http.StatusOK},
+		{"/readyz", http.StatusServiceUnavailable},
+		{"/readyz?service=unknown.Service", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		if code, body := get(t, s.healthHandler(), tt.path); code != tt.code {
+			t.Errorf("%s = %d %q, want %d", tt.path, code, body, tt.code)
+		}
+	}
+}
+
+// get returns the status code and the body of the response of the handler.
+func get(t *testing.T, h http.Handler, path string) (int, string) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+	body, err := io.ReadAll(rec.Result().Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rec.Code, string(body)
+}
@@ -0,0 +1,33 @@
+package serverkit
+
+import (
+	"context"
+	"log"
+	"net"
+	"net/http"
+	"time"
+)
+
+// serveHTTP serves the handler on the listener until shutdownHTTP.
+func serveHTTP(name string, listener net.Listener, handler http.Handler) *http.Server {
+	srv := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		if err := srv.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Printf("%s HTTP server: %v", name, err)
+		}
+	}()
+
+	return srv
+}
+
+// shutdownHTTP stops the server once its requests are answered.
+func shutdownHTTP(srv *http.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_ = srv.Shutdown(ctx)
+}
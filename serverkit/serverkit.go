@@ -0,0 +1,276 @@
+// Package serverkit builds and runs the gRPC servers of the services:
+// the listener, the TLS, the interceptors, the health and reflection services,
+// the probes of the dependencies, the HTTP health, the Prometheus metrics
+// and the graceful shutdown on SIGINT and SIGTERM.
+package serverkit
+
+import (
+	"context"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sergeyzalunin/grpc-go-course/config"
+	"github.com/sergeyzalunin/grpc-go-course/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// DefaultDrainTimeout is how long the calls in flight may run on shutdown.
+const DefaultDrainTimeout = 10 * time.Second
+
+// Interceptors are the interceptors of the calls, run in order.
+type Interceptors struct {
+	Unary  []grpc.UnaryServerInterceptor
+	Stream []grpc.StreamServerInterceptor
+}
+
+// Config configures a server, the fields with the yaml tags are loaded by the config package.
+type Config struct {
+	Name         string // the name of the service in the logs
+	Addr         string `yaml:"addr" help:"address to listen on"`
+	TLS          TLS    `yaml:"tls"`
+	Interceptors Interceptors
+	Reflection   bool `yaml:"reflection" help:"serve the reflection service"`
+
+	// how long the calls in flight may run on shutdown before they are cancelled,
+	// DefaultDrainTimeout when 0
+	DrainTimeout time.Duration `yaml:"drain_timeout" help:"how long the calls in flight may run on shutdown"`
+
+	// the address of the HTTP /healthz and /readyz, not served when empty
+	HealthAddr    string        `yaml:"health_addr" help:"address of the HTTP /healthz and /readyz, off when empty"`
+	ProbeInterval time.Duration `yaml:"probe_interval" help:"how often the dependencies are probed"`
+
+	// the address of the HTTP /metrics, not served and not recorded when empty
+	MetricsAddr string `yaml:"metrics_addr" help:"address of the HTTP /metrics, off when empty"`
+}
+
+// DefaultConfig returns the config of the service listening on the address
+// without TLS, its certificate files are those of gen_cert.sh.
+func DefaultConfig(name, addr string) Config {
+	return Config{
+		Name: name,
+		Addr: addr,
+		TLS: TLS{
+			CertFile: DefaultCertFile,
+			KeyFile:  DefaultKeyFile,
+		},
+		Reflection:    true,
+		DrainTimeout:  DefaultDrainTimeout,
+		ProbeInterval: DefaultProbeInterval,
+	}
+}
+
+// Validate checks the loaded config.
+func (c *Config) Validate(check *config.Check) {
+	check.Addr("addr", c.Addr)
+
+	if c.DrainTimeout < 0 {
+		check.Errorf("drain_timeout", "must not be negative")
+	}
+
+	if c.HealthAddr != "" {
+		check.Addr("health_addr", c.HealthAddr)
+	}
+
+	if c.MetricsAddr != "" {
+		check.Addr("metrics_addr", c.MetricsAddr)
+	}
+
+	if c.ProbeInterval <= 0 {
+		check.Errorf("probe_interval", "must be positive")
+	}
+
+	if c.TLS.enabled() && !c.TLS.DevCert {
+		if c.TLS.CertFile == "" || c.TLS.KeyFile == "" {
+			check.Errorf("tls", "cert_file and key_file are required, or dev_cert")
+		}
+
+		check.File("tls.cert_file", c.TLS.CertFile)
+		check.File("tls.key_file", c.TLS.KeyFile)
+	}
+
+	check.File("tls.client_ca", c.TLS.ClientCAFile)
+}
+
+// Server is a gRPC server listening on its address.
+// The services are registered to GRPC before Run.
+type Server struct {
+	GRPC   *grpc.Server
+	Health *health.Server
+
+	config          Config
+	listener        net.Listener
+	healthListener  net.Listener         // of the HTTP health, nil when it is not served
+	metricsListener net.Listener         // of the HTTP metrics, nil when they are not served
+	metrics         *prometheus.Registry // of the metrics of the calls
+	stop            context.CancelFunc   // stops the watchers of the server
+
+	mu     sync.Mutex // guards the results of the probes
+	probes []*probe
+}
+
+// New returns the server of the config listening on its address.
+func New(config Config) (*Server, error) {
+	ctx, stop := context.WithCancel(context.Background())
+
+	var registry *prometheus.Registry
+
+	interceptors := config.Interceptors
+
+	if config.MetricsAddr != "" {
+		// first, so the calls refused by the other interceptors are recorded too
+		registry = metrics.NewRegistry()
+		m := metrics.NewServerMetrics(registry)
+
+		interceptors.Unary = append([]grpc.UnaryServerInterceptor{m.UnaryServerInterceptor()}, interceptors.Unary...)
+		interceptors.Stream = append([]grpc.StreamServerInterceptor{m.StreamServerInterceptor()}, interceptors.Stream...)
+	}
+
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(interceptors.Unary...),
+		grpc.ChainStreamInterceptor(interceptors.Stream...),
+	}
+
+	if config.TLS.enabled() {
+		creds, err := config.TLS.credentials(ctx, config.Name)
+		if err != nil {
+			stop()
+
+			return nil, err
+		}
+
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	listener, err := net.Listen("tcp", config.Addr)
+	if err != nil {
+		stop()
+
+		return nil, err
+	}
+
+	s := &Server{
+		GRPC:     grpc.NewServer(opts...),
+		Health:   health.NewServer(),
+		config:   config,
+		listener: listener,
+		metrics:  registry,
+		stop:     stop,
+	}
+
+	if config.HealthAddr != "" {
+		if s.healthListener, err = net.Listen("tcp", config.HealthAddr); err != nil {
+			stop()
+			listener.Close()
+
+			return nil, err
+		}
+	}
+
+	if config.MetricsAddr != "" {
+		if s.metricsListener, err = net.Listen("tcp", config.MetricsAddr); err != nil {
+			stop()
+			listener.Close()
+
+			if s.healthListener != nil {
+				s.healthListener.Close()
+			}
+
+			return nil, err
+		}
+	}
+
+	healthpb.RegisterHealthServer(s.GRPC, s.Health)
+
+	if config.Reflection {
+		reflection.Register(s.GRPC)
+	}
+
+	return s, nil
+}
+
+// Run serves the calls until SIGINT or SIGTERM is received or the context is done,
+// then stops the server gracefully.
+func (s *Server) Run(ctx context.Context) error {
+	defer s.stop()
+
+	ctx, stopSignals := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+
+	// the services are serving once their dependencies are reachable
+	s.checkProbes(ctx)
+	go s.runProbes(ctx)
+
+	if s.healthListener != nil {
+		healthServer := serveHTTP("Health", s.healthListener, s.healthHandler())
+		defer shutdownHTTP(healthServer)
+
+		log.Printf("%s health served on http://%s/readyz", s.config.Name, s.healthListener.Addr())
+	}
+
+	if s.metricsListener != nil {
+		metricsServer := serveHTTP("Metrics", s.metricsListener, metrics.Handler(s.metrics))
+		defer shutdownHTTP(metricsServer)
+
+		log.Printf("%s metrics served on http://%s/metrics", s.config.Name, s.metricsListener.Addr())
+	}
+
+	served := make(chan error, 1)
+
+	go func() {
+		served <- s.GRPC.Serve(s.listener)
+	}()
+
+	log.Printf("%s server listening on %s", s.config.Name, s.listener.Addr())
+
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+	}
+
+	// a second signal kills the process at once
+	stopSignals()
+
+	log.Printf("Stopping the %s server", s.config.Name)
+	s.shutdown()
+
+	return nil
+}
+
+// shutdown reports the services not serving, so the clients checking the health
+// go elsewhere, then waits for the calls in flight up to the drain timeout.
+func (s *Server) shutdown() {
+	s.Health.Shutdown()
+
+	timeout := s.config.DrainTimeout
+	if timeout == 0 {
+		timeout = DefaultDrainTimeout
+	}
+
+	stopped := make(chan struct{})
+
+	go func() {
+		s.GRPC.GracefulStop()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-stopped:
+	case <-timer.C:
+		log.Printf("Calls still running after %s, cancelling them", timeout)
+		s.GRPC.Stop()
+		<-stopped
+	}
+}
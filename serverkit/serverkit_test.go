@@ -0,0 +1,139 @@
+package serverkit
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sergeyzalunin/grpc-go-course/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(c *Config)
+		wantErr bool
+	}{
+		{"default", func(*Config) {}, false},
+		{"dev cert", func(c *Config) { c.TLS = TLS{DevCert: true} }, false},
+		{"negative drain timeout", func(c *Config) { c.DrainTimeout = -time.Second }, true},
+		{"no probe interval", func(c *Config) { c.ProbeInterval = 0 }, true},
+		{"bad addr", func(c *Config) { c.Addr = "localhost" }, true},
+		{"bad health addr", func(c *Config) { c.HealthAddr = "localhost" }, true},
+		{"missing cert", func(c *Config) {
+			c.TLS = TLS{Enabled: true, CertFile: filepath.Join(t.TempDir(), "cert.pem"), KeyFile: c.TLS.KeyFile}
+		}, true},
+		{"no cert files", func(c *Config) { c.TLS = TLS{Enabled: true} }, true},
+		{"missing client CA", func(c *Config) { c.TLS.ClientCAFile = filepath.Join(t.TempDir(), "ca.pem") }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := DefaultConfig("greet", "localhost:50051")
+			tt.change(&c)
+
+			check := &config.Check{}
+			c.Validate(check)
+
+			if err := check.Err(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{"plain", Config{Name: "greet", Addr: "localhost:0"}, false},
+		{"dev cert", Config{Name: "greet", Addr: "localhost:0", TLS: TLS{Enabled: true, DevCert: true}}, false},
+		{"bad addr", Config{Name: "greet", Addr: "localhost"}, true},
+		{"missing cert", Config{
+			Name: "greet",
+			Addr: "localhost:0",
+			TLS:  TLS{Enabled: true, CertFile: filepath.Join(t.TempDir(), "cert.pem"), KeyFile: filepath.Join(t.TempDir(), "key.pem")},
+		}, true},
+		{"missing client CA", Config{
+			Name: "greet",
+			Addr: "localhost:0",
+			TLS:  TLS{Enabled: true, DevCert: true, ClientCAFile: filepath.Join(t.TempDir(), "ca.pem")},
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err == nil {
+				s.listener.Close()
+				s.stop()
+			}
+		})
+	}
+}
+
+func TestServerRun(t *testing.T) {
+	c := DefaultConfig("greet", "localhost:0")
+	c.DrainTimeout = 100 * time.Millisecond
+
+	s, err := New(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ran := make(chan error, 1)
+
+	go func() {
+		ran <- s.Run(ctx)
+	}()
+
+	cc, err := grpc.NewClient(s.listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cc.Close()
+
+	// a watch keeps a call in flight until the drain timeout cancels it
+	watch, err := healthpb.NewHealthClient(cc).Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := watch.Recv()
+	if err != nil || res.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("Watch() = %v, %v, want SERVING", res, err)
+	}
+
+	cancel()
+
+	// the watchers are told the server is going away
+	if res, err = watch.Recv(); err != nil || res.GetStatus() != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Watch() on shutdown = %v, %v, want NOT_SERVING", res, err)
+	}
+
+	if res, err = watch.Recv(); err == nil {
+		t.Errorf("Watch() after the drain timeout = %v, want the call cancelled", res)
+	}
+
+	select {
+	case err = <-ran:
+		if err != nil {
+			t.Errorf("Run() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run() did not return after the drain timeout")
+	}
+}
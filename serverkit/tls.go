@@ -0,0 +1,102 @@
+package serverkit
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sergeyzalunin/grpc-go-course/certs"
+	"google.golang.org/grpc/credentials"
+)
+
+// the certificate made by gen_cert.sh, relative to the directory of a server
+const (
+	DefaultCertFile = "../../ssl/localhost/cert.pem"
+	DefaultKeyFile  = "../../ssl/localhost/key.pem"
+)
+
+// TLS configures the TLS of a server.
+type TLS struct {
+	// TLS is also used when DevCert or ClientCAFile are set
+	Enabled bool `yaml:"enabled" flag:"tls" help:"serve TLS"`
+
+	// the certificate of the server, reloaded when the files change
+	CertFile string `yaml:"cert_file" flag:"cert" help:"certificate of the server"`
+	KeyFile  string `yaml:"key_file" flag:"key" help:"key of the certificate of the server"`
+
+	// an ephemeral self-signed certificate is used instead of the files
+	DevCert bool `yaml:"dev_cert" flag:"dev-cert" help:"use an ephemeral self-signed certificate instead of the files"`
+
+	// the clients must present a certificate signed by the CA of the file when set
+	ClientCAFile string `yaml:"client_ca" flag:"client-ca" help:"CA certificate of the client certificates, required when set"`
+}
+
+func (t *TLS) enabled() bool {
+	return t.Enabled || t.DevCert || t.ClientCAFile != ""
+}
+
+// credentials returns the TLS credentials of the server.
+// The certificate files are watched until the context is done.
+func (t *TLS) credentials(ctx context.Context, name string) (credentials.TransportCredentials, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if t.DevCert {
+		cert, err := devCertificate(name)
+		if err != nil {
+			return nil, err
+		}
+
+		config.Certificates = []tls.Certificate{cert}
+	} else {
+		manager, err := certs.NewManager(t.CertFile, t.KeyFile, certs.DefaultWarnBefore)
+		if err != nil {
+			return nil, fmt.Errorf("loading certificates: %w", err)
+		}
+
+		// the rewritten certificate files are used for the new connections
+		go manager.Watch(ctx, certs.DefaultCheckInterval)
+
+		config.GetCertificate = manager.GetCertificate
+	}
+
+	if t.ClientCAFile != "" {
+		pem, err := os.ReadFile(t.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client CA: %w", err)
+		}
+
+		config.ClientCAs = x509.NewCertPool()
+		if !config.ClientCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates in client CA %s", t.ClientCAFile)
+		}
+
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return credentials.NewTLS(config), nil
+}
+
+// devCertificate returns an ephemeral self-signed certificate of localhost,
+// valid for a day, and writes it to the temporary directory for the clients to trust.
+func devCertificate(name string) (tls.Certificate, error) {
+	cert, certPEM, err := certs.SelfSigned([]string{"localhost", "127.0.0.1", "::1"}, 24*time.Hour)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	caFile := filepath.Join(os.TempDir(), name+"-dev-cert.pem")
+	if err = os.WriteFile(caFile, certPEM, 0o644); err != nil {
+		return tls.Certificate{}, err
+	}
+
+	log.Printf("Using an ephemeral self-signed certificate, the clients trust it with SERVER_CA=%s", caFile)
+
+	return cert, nil
+}